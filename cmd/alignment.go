@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/steipete/sag/internal/subtitles"
+)
+
+// alignmentFile is the --alignment sidecar: word and character timings plus the request that produced them.
+type alignmentFile struct {
+	Provider   string           `json:"provider"`
+	ModelID    string           `json:"model_id"`
+	VoiceID    string           `json:"voice_id"`
+	Output     string           `json:"output,omitempty"`
+	Format     string           `json:"format,omitempty"`
+	Request    any              `json:"request"`
+	Words      []subtitles.Word `json:"words"`
+	Characters []subtitles.Char `json:"characters,omitempty"`
+}
+
+func writeAlignment(path, provider string, opts speakOptions, req ttsRequest, timings subtitles.Timings) error {
+	words := timings.AllWords()
+	if words == nil {
+		words = []subtitles.Word{}
+	}
+	data, err := json.MarshalIndent(alignmentFile{
+		Provider:   provider,
+		ModelID:    opts.modelID,
+		VoiceID:    req.voiceID,
+		Output:     opts.outputPath,
+		Format:     opts.outputFmt,
+		Request:    req.payload,
+		Words:      words,
+		Characters: timings.Chars,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -27,10 +27,11 @@ func ensureAPIKey() error {
 }
 
 func ensureAPIKeyForProvider(provider string) error {
-	if provider == "minimax" {
-		return ensureMiniMaxAPIKey()
+	spec, err := lookupProvider(provider)
+	if err != nil {
+		return err
 	}
-	return ensureAPIKey()
+	return spec.ensureAPIKey()
 }
 
 func ensureMiniMaxAPIKey() error {
@@ -53,35 +54,43 @@ func ensureMiniMaxAPIKey() error {
 	return nil
 }
 
-func resolveAPIKeyFromFile() (string, error) {
-	path := cfg.APIKeyFile
-	if path == "" {
-		path = os.Getenv("ELEVENLABS_API_KEY_FILE")
-	}
-	if path == "" {
-		path = os.Getenv("SAG_API_KEY_FILE")
+func ensureOpenAIAPIKey() error {
+	if cfg.APIKey == "" {
+		key, err := resolveAPIKeyFileFromEnv("OPENAI_API_KEY_FILE", "SAG_API_KEY_FILE")
+		if err != nil {
+			return err
+		}
+		cfg.APIKey = key
 	}
-	if path == "" {
-		return "", nil
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv("OPENAI_API_KEY")
 	}
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", fmt.Errorf("read api key file: %w", err)
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv("SAG_API_KEY")
 	}
-	key := strings.TrimSpace(string(data))
-	if key == "" {
-		return "", fmt.Errorf("api key file %q is empty", path)
+	// Self-hosted OpenAI-compatible servers usually run without auth.
+	if cfg.APIKey == "" && openAIBaseURL() == "" {
+		return fmt.Errorf("missing OpenAI API key (set --api-key, --api-key-file, or OPENAI_API_KEY; not needed with OPENAI_BASE_URL for self-hosted servers)")
 	}
-	return key, nil
+	return nil
+}
+
+func resolveAPIKeyFromFile() (string, error) {
+	return resolveAPIKeyFileFromEnv("ELEVENLABS_API_KEY_FILE", "SAG_API_KEY_FILE")
 }
 
 func resolveMiniMaxAPIKeyFromFile() (string, error) {
+	return resolveAPIKeyFileFromEnv("MINIMAX_API_KEY_FILE", "SAG_API_KEY_FILE")
+}
+
+// resolveAPIKeyFileFromEnv reads the key from --api-key-file or the first set env var naming a file.
+func resolveAPIKeyFileFromEnv(envVars ...string) (string, error) {
 	path := cfg.APIKeyFile
-	if path == "" {
-		path = os.Getenv("MINIMAX_API_KEY_FILE")
-	}
-	if path == "" {
-		path = os.Getenv("SAG_API_KEY_FILE")
+	for _, env := range envVars {
+		if path != "" {
+			break
+		}
+		path = os.Getenv(env)
 	}
 	if path == "" {
 		return "", nil
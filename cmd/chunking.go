@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/steipete/sag/internal/chunker"
+
+	"github.com/spf13/cobra"
+)
+
+// maxPreviousRequestIDs is how many earlier request IDs ElevenLabs accepts for stitching.
+const maxPreviousRequestIDs = 3
+
+// splitForProvider chunks text to the model's request limit (or --chunk-size when smaller).
+func splitForProvider(spec providerSpec, opts speakOptions, text string) []string {
+	limit := spec.maxChars(opts.modelID)
+	if opts.chunkSize > 0 && (limit == 0 || opts.chunkSize < limit) {
+		limit = opts.chunkSize
+	}
+	return chunker.Split(text, limit)
+}
+
+// checkChunkable rejects combinations that cannot be stitched from separate requests.
+func checkChunkable(opts speakOptions, chunks int) error {
+	if chunks <= 1 {
+		return nil
+	}
+	if opts.wantsTimings() {
+		return fmt.Errorf("text needs %d requests; --subtitles/--alignment only support a single request (shorten the text or raise --chunk-size)", chunks)
+	}
+	if !concatenableFormat(opts.outputFmt) {
+		return fmt.Errorf("text needs %d requests, but %s audio cannot be joined; use an mp3 or raw pcm format", chunks, opts.outputFmt)
+	}
+	return nil
+}
+
+// concatenableFormat reports whether audio in format can be joined by appending bytes.
+func concatenableFormat(format string) bool {
+	format = strings.ToLower(format)
+	for _, prefix := range []string{"mp3", "pcm", "ulaw", "alaw"} {
+		if strings.HasPrefix(format, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// synthesizeChunks builds a request per chunk up front (so flag errors surface before any audio),
+// then generates them in order into one continuous stream.
+func synthesizeChunks(ctx context.Context, cmd *cobra.Command, provider ttsProvider, opts speakOptions, chunks []string) (io.ReadCloser, error) {
+	reqs := make([]ttsRequest, len(chunks))
+	for i, chunk := range chunks {
+		req, err := provider.BuildRequest(cmd, opts, chunk)
+		if err != nil {
+			return nil, err
+		}
+		reqs[i] = req
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	out := newChunkedAudio(cancel)
+	go func() {
+		out.finish(generateChunks(ctx, provider, opts, chunks, reqs, out))
+	}()
+	return out, nil
+}
+
+func generateChunks(ctx context.Context, provider ttsProvider, opts speakOptions, chunks []string, reqs []ttsRequest, out *chunkedAudio) error {
+	stitcher, canStitch := provider.(stitchingProvider)
+	var requestIDs []string
+	for i, req := range reqs {
+		var (
+			body io.ReadCloser
+			err  error
+		)
+		switch {
+		case canStitch:
+			cc := chunkContext{previousRequestIDs: requestIDs}
+			if i > 0 {
+				cc.previousText = chunks[i-1]
+			}
+			if i+1 < len(chunks) {
+				cc.nextText = chunks[i+1]
+			}
+			var id string
+			body, id, err = stitcher.StreamStitched(ctx, req, cc)
+			if id != "" {
+				requestIDs = append(requestIDs, id)
+				if len(requestIDs) > maxPreviousRequestIDs {
+					requestIDs = requestIDs[len(requestIDs)-maxPreviousRequestIDs:]
+				}
+			}
+		case opts.stream && provider.Capabilities().Streaming:
+			body, err = provider.Stream(ctx, req)
+		default:
+			var data []byte
+			data, err = provider.Convert(ctx, req)
+			body = io.NopCloser(bytes.NewReader(data))
+		}
+		if err != nil {
+			return fmt.Errorf("chunk %d/%d: %w", i+1, len(reqs), err)
+		}
+		// Drain each response at network speed rather than playback speed so long chunks
+		// don't run into HTTP client timeouts while earlier audio is still playing.
+		_, err = io.Copy(out, body)
+		_ = body.Close()
+		if err != nil {
+			return fmt.Errorf("chunk %d/%d: %w", i+1, len(reqs), err)
+		}
+	}
+	return nil
+}
+
+// chunkedAudio is an unbounded in-memory pipe: writes never block, reads wait for data.
+type chunkedAudio struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  [][]byte
+	done   bool
+	err    error
+	closed bool
+	cancel context.CancelFunc
+}
+
+func newChunkedAudio(cancel context.CancelFunc) *chunkedAudio {
+	a := &chunkedAudio{cancel: cancel}
+	a.cond = sync.NewCond(&a.mu)
+	return a
+}
+
+func (a *chunkedAudio) Write(p []byte) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return 0, io.ErrClosedPipe
+	}
+	a.queue = append(a.queue, append([]byte(nil), p...))
+	a.cond.Broadcast()
+	return len(p), nil
+}
+
+func (a *chunkedAudio) finish(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.done = true
+	if !errors.Is(err, context.Canceled) || !a.closed {
+		a.err = err
+	}
+	a.cond.Broadcast()
+}
+
+func (a *chunkedAudio) Read(p []byte) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for len(a.queue) == 0 && !a.done && !a.closed {
+		a.cond.Wait()
+	}
+	if a.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if len(a.queue) == 0 {
+		if a.err != nil {
+			return 0, a.err
+		}
+		return 0, io.EOF
+	}
+	n := copy(p, a.queue[0])
+	if n == len(a.queue[0]) {
+		a.queue = a.queue[1:]
+	} else {
+		a.queue[0] = a.queue[0][n:]
+	}
+	return n, nil
+}
+
+func (a *chunkedAudio) Close() error {
+	a.mu.Lock()
+	a.closed = true
+	a.queue = nil
+	a.cond.Broadcast()
+	a.mu.Unlock()
+	a.cancel()
+	return nil
+}
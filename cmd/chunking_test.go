@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+)
+
+func TestSynthesizeChunksStitchesElevenLabsRequests(t *testing.T) {
+	var bodies []elevenlabs.TTSRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body elevenlabs.TTSRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		bodies = append(bodies, body)
+		w.Header().Set("request-id", fmt.Sprintf("req-%d", len(bodies)))
+		_, _ = w.Write([]byte("<" + body.Text + ">"))
+	}))
+	defer srv.Close()
+
+	cmd, opts := newSpeakTestCommand(t)
+	opts.outputPath = filepath.Join(t.TempDir(), "out.mp3")
+	opts.voiceID = "v1"
+	chunks := []string{"One.", "Two.", "Three.", "Four.", "Five."}
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+
+	resp, err := synthesizeChunks(context.Background(), cmd, provider, *opts, chunks)
+	if err != nil {
+		t.Fatalf("synthesizeChunks error: %v", err)
+	}
+	if _, err := playStream(context.Background(), *opts, resp); err != nil {
+		t.Fatalf("playStream error: %v", err)
+	}
+	data, err := os.ReadFile(opts.outputPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "<One.><Two.><Three.><Four.><Five.>" {
+		t.Fatalf("unexpected stitched output: %q", string(data))
+	}
+
+	if len(bodies) != 5 {
+		t.Fatalf("expected 5 requests, got %d", len(bodies))
+	}
+	if bodies[0].PreviousText != "" || bodies[0].NextText != "Two." || len(bodies[0].PreviousRequestIDs) != 0 {
+		t.Fatalf("unexpected first request context: %+v", bodies[0])
+	}
+	if bodies[2].PreviousText != "Two." || bodies[2].NextText != "Four." {
+		t.Fatalf("unexpected middle request context: %+v", bodies[2])
+	}
+	if got := strings.Join(bodies[4].PreviousRequestIDs, ","); got != "req-2,req-3,req-4" {
+		t.Fatalf("previous_request_ids = %q, want last three", got)
+	}
+}
+
+func TestSynthesizeChunksSkipsStitchingForV3(t *testing.T) {
+	var sawContext bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body elevenlabs.TTSRequest
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.PreviousText != "" || body.NextText != "" || len(body.PreviousRequestIDs) > 0 {
+			sawContext = true
+		}
+		w.Header().Set("request-id", "id")
+		_, _ = w.Write([]byte("x"))
+	}))
+	defer srv.Close()
+
+	cmd, opts := newSpeakTestCommand(t)
+	opts.modelID = "eleven_v3"
+	opts.outputPath = filepath.Join(t.TempDir(), "out.mp3")
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+	resp, err := synthesizeChunks(context.Background(), cmd, provider, *opts, []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("synthesizeChunks error: %v", err)
+	}
+	if _, err := playStream(context.Background(), *opts, resp); err != nil {
+		t.Fatalf("playStream error: %v", err)
+	}
+	if sawContext {
+		t.Fatalf("eleven_v3 requests must not carry stitching context")
+	}
+}
+
+func TestSynthesizeChunksReportsFailingChunk(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 2 {
+			http.Error(w, "quota", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	cmd, opts := newSpeakTestCommand(t)
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+	resp, err := synthesizeChunks(context.Background(), cmd, provider, *opts, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("synthesizeChunks error: %v", err)
+	}
+	defer func() { _ = resp.Close() }()
+	_, err = io.ReadAll(resp)
+	if err == nil || !strings.Contains(err.Error(), "chunk 2/3") {
+		t.Fatalf("expected chunk 2 error, got %v", err)
+	}
+}
+
+func TestSplitForProviderUsesModelLimitAndChunkSize(t *testing.T) {
+	spec, err := lookupProvider(providerElevenLabs)
+	if err != nil {
+		t.Fatalf("lookupProvider error: %v", err)
+	}
+	text := strings.Repeat("Sentence number here. ", 400) // ~8800 chars
+	if got := splitForProvider(spec, speakOptions{modelID: "eleven_v3"}, text); len(got) != 2 {
+		t.Fatalf("eleven_v3 chunks = %d, want 2", len(got))
+	}
+	if got := splitForProvider(spec, speakOptions{modelID: "eleven_flash_v2_5"}, text); len(got) != 1 {
+		t.Fatalf("eleven_flash_v2_5 chunks = %d, want 1", len(got))
+	}
+	if got := splitForProvider(spec, speakOptions{modelID: "eleven_flash_v2_5", chunkSize: 1000}, text); len(got) < 9 {
+		t.Fatalf("--chunk-size 1000 chunks = %d, want >= 9", len(got))
+	}
+}
+
+func TestCheckChunkable(t *testing.T) {
+	if err := checkChunkable(speakOptions{outputFmt: "wav"}, 1); err != nil {
+		t.Fatalf("single chunk should always pass: %v", err)
+	}
+	if err := checkChunkable(speakOptions{outputFmt: "mp3_44100_128"}, 3); err != nil {
+		t.Fatalf("mp3 chunks should pass: %v", err)
+	}
+	if err := checkChunkable(speakOptions{outputFmt: "wav"}, 3); err == nil {
+		t.Fatalf("expected wav chunking to be rejected")
+	}
+	if err := checkChunkable(speakOptions{outputFmt: "mp3", subtitlesPath: "x.srt"}, 3); err == nil {
+		t.Fatalf("expected timings with chunking to be rejected")
+	}
+}
+
+func TestChunkedAudioCloseUnblocksReader(t *testing.T) {
+	canceled := false
+	a := newChunkedAudio(func() { canceled = true })
+	done := make(chan error, 1)
+	go func() {
+		_, err := a.Read(make([]byte, 4))
+		done <- err
+	}()
+	_ = a.Close()
+	if err := <-done; !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("Read after Close = %v, want ErrClosedPipe", err)
+	}
+	if !canceled {
+		t.Fatalf("Close should cancel generation")
+	}
+}
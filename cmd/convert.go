@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
+)
+
+// stsModels are the ElevenLabs speech-to-speech models.
+var stsModels = []string{"eleven_multilingual_sts_v2", "eleven_english_sts_v2"}
+
+type convertOptions struct {
+	speakOptions
+	removeBackgroundNoise bool
+}
+
+func init() {
+	opts := convertOptions{speakOptions: speakOptions{
+		modelID:   stsModels[0],
+		outputFmt: "mp3_44100_128",
+		stream:    true,
+		play:      true,
+		speed:     1.0,
+	}}
+
+	cmd := &cobra.Command{
+		Use:   "convert <input-audio>",
+		Short: "Re-voice recorded speech with an ElevenLabs voice (speech-to-speech)",
+		Long:  "Sends recorded audio to ElevenLabs speech-to-speech, keeping its timing and delivery while changing the voice. Use '-' to read audio from stdin.",
+		Example: "  sag convert --voice Roger scratch.wav -o final.mp3\n" +
+			"  sag convert -v Roger --remove-background-noise take3.m4a",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			model, err := resolveSTSModel(opts.modelID)
+			if err != nil {
+				return err
+			}
+			opts.modelID = model
+
+			spec, err := lookupProvider(providerElevenLabs)
+			if err != nil {
+				return err
+			}
+			provider := spec.newProvider()
+			forceVoiceID := cmd.Flags().Changed("voice-id")
+			voiceInput := opts.voiceID
+			if voiceInput == "" {
+				if env := voiceFromEnv(spec); env != "" {
+					voiceInput = env
+					forceVoiceID = true
+				}
+			}
+			if opts.voiceID, err = provider.ResolveVoice(cmd.Context(), voiceInput, forceVoiceID); err != nil {
+				return err
+			}
+
+			applyOutputPath(cmd, provider, &opts.speakOptions)
+			if err := checkPlayer(opts.play); err != nil {
+				return err
+			}
+			req, err := buildSTSRequest(cmd, opts)
+			if err != nil {
+				return err
+			}
+			filename, input, err := readConvertInput(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+			defer cancel()
+			start := time.Now()
+			var n int64
+			if opts.stream {
+				var resp io.ReadCloser
+				if resp, err = client.StreamSTS(ctx, opts.voiceID, filename, bytes.NewReader(input), req); err == nil {
+					n, err = playStream(ctx, opts.speakOptions, resp)
+				}
+			} else {
+				var data []byte
+				if data, err = client.ConvertSTS(ctx, opts.voiceID, filename, bytes.NewReader(input), req); err == nil {
+					n, err = playData(ctx, opts.speakOptions, data)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if opts.metrics {
+				fmt.Fprintf(os.Stderr, "metrics: input_bytes=%d bytes=%d model=%s voice=%s stream=%t latencyTier=%d dur=%s\n",
+					len(input), n, opts.modelID, opts.voiceID, opts.stream, opts.latencyTier, time.Since(start).Truncate(time.Millisecond))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.voiceID, "voice-id", "", "Target voice ID (ELEVENLABS_VOICE_ID)")
+	cmd.Flags().StringVarP(&opts.voiceID, "voice", "v", "", "Alias for --voice-id; accepts name or ID")
+	cmd.Flags().StringVar(&opts.modelID, "model-id", opts.modelID, "Speech-to-speech model: "+strings.Join(stsModels, "|"))
+	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "Write audio to file (disables playback unless --play is also set)")
+	cmd.Flags().StringVar(&opts.outputFmt, "format", opts.outputFmt, "Output format (e.g. mp3_44100_128, pcm_44100)")
+	cmd.Flags().BoolVar(&opts.stream, "stream", opts.stream, "Stream audio while converting")
+	cmd.Flags().BoolVar(&opts.play, "play", opts.play, "Play audio through speakers")
+	cmd.Flags().IntVar(&opts.latencyTier, "latency-tier", 0, "Streaming latency tier (0=default,1-4 lower latency may cost more)")
+	cmd.Flags().Float64Var(&opts.stability, "stability", 0, "Voice stability (0..1)")
+	cmd.Flags().Float64Var(&opts.similarity, "similarity", 0, "Voice similarity boost (0..1)")
+	cmd.Flags().Float64Var(&opts.similarity, "similarity-boost", 0, "Alias for --similarity")
+	cmd.Flags().Float64Var(&opts.style, "style", 0, "Voice style exaggeration (0..1)")
+	cmd.Flags().BoolVar(&opts.speakerBoost, "speaker-boost", false, "Enable speaker boost")
+	cmd.Flags().BoolVar(&opts.noSpeakerBoost, "no-speaker-boost", false, "Disable speaker boost")
+	cmd.Flags().Uint64Var(&opts.seed, "seed", 0, "Best-effort deterministic seed (0..4294967295)")
+	cmd.Flags().BoolVar(&opts.removeBackgroundNoise, "remove-background-noise", false, "Isolate the voice from background noise before converting")
+	cmd.Flags().BoolVar(&opts.metrics, "metrics", false, "Print request metrics to stderr")
+	rootCmd.AddCommand(cmd)
+}
+
+func resolveSTSModel(modelID string) (string, error) {
+	for _, m := range stsModels {
+		if strings.EqualFold(m, strings.TrimSpace(modelID)) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unknown speech-to-speech model %q (valid: %s)", modelID, strings.Join(stsModels, ", "))
+}
+
+// buildSTSRequest reuses the speak voice-setting flags and their validation.
+func buildSTSRequest(cmd *cobra.Command, opts convertOptions) (elevenlabs.STSRequest, error) {
+	tts, err := buildTTSRequest(cmd, opts.speakOptions, "")
+	if err != nil {
+		return elevenlabs.STSRequest{}, err
+	}
+	settings := tts.VoiceSettings
+	// Speech-to-speech keeps the input's pacing, so speed is not sent.
+	settings.Speed = nil
+	if *settings == (elevenlabs.VoiceSettings{}) {
+		settings = nil
+	}
+	return elevenlabs.STSRequest{
+		ModelID:               opts.modelID,
+		OutputFormat:          opts.outputFmt,
+		VoiceSettings:         settings,
+		Seed:                  tts.Seed,
+		RemoveBackgroundNoise: opts.removeBackgroundNoise,
+		Latency:               opts.latencyTier,
+	}, nil
+}
+
+func readConvertInput(path string) (string, []byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(data) == 0 {
+			return "", nil, fmt.Errorf("stdin was empty")
+		}
+		return "input", data, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(data) == 0 {
+		return "", nil, fmt.Errorf("%s is empty", path)
+	}
+	return filepath.Base(path), data, nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvertCommandStreamsAndWritesOutput(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "scratch.wav")
+	if err := os.WriteFile(input, []byte("RIFFscratch"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Query().Get("output_format") != "mp3_44100_128" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		if r.FormValue("model_id") != "eleven_multilingual_sts_v2" {
+			t.Fatalf("unexpected model %q", r.FormValue("model_id"))
+		}
+		if _, ok := r.MultipartForm.Value["voice_settings"]; ok {
+			t.Fatalf("voice_settings should be omitted without flags: %v", r.MultipartForm.Value)
+		}
+		f, hdr, err := r.FormFile("audio")
+		if err != nil {
+			t.Fatalf("audio: %v", err)
+		}
+		data, _ := io.ReadAll(f)
+		if hdr.Filename != "scratch.wav" || string(data) != "RIFFscratch" {
+			t.Fatalf("unexpected audio upload %s %q", hdr.Filename, data)
+		}
+		_, _ = w.Write([]byte("ID3converted"))
+	}))
+	defer srv.Close()
+
+	out := filepath.Join(dir, "final.mp3")
+	if _, err := executeRoot(t, srv.URL, "convert", "--voice-id", testVoiceID, input, "-o", out); err != nil {
+		t.Fatalf("convert (stream): %v", err)
+	}
+	if _, err := executeRoot(t, srv.URL, "convert", "--voice-id", testVoiceID, "--stream=false", input, "-o", out); err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+
+	want := []string{
+		"/v1/speech-to-speech/" + testVoiceID + "/stream",
+		"/v1/speech-to-speech/" + testVoiceID,
+	}
+	if len(paths) != 2 || paths[0] != want[0] || paths[1] != want[1] {
+		t.Fatalf("unexpected requests %v", paths)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil || string(data) != "ID3converted" {
+		t.Fatalf("unexpected output %q, %v", data, err)
+	}
+}
+
+func TestResolveSTSModel(t *testing.T) {
+	if m, err := resolveSTSModel("ELEVEN_ENGLISH_STS_V2"); err != nil || m != "eleven_english_sts_v2" {
+		t.Fatalf("resolveSTSModel = %q, %v", m, err)
+	}
+	if _, err := resolveSTSModel("eleven_v3"); err == nil {
+		t.Fatal("expected error for TTS model")
+	}
+}
@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/audio"
+	"github.com/steipete/sag/internal/dialogue"
+	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type dialogueOptions struct {
+	speakOptions
+	gap     time.Duration
+	perLine bool
+}
+
+// dialogueSettingFlags are the voice settings text-to-dialogue accepts; any other setting needs per-line rendering.
+var dialogueSettingFlags = map[string]bool{"stability": true, "seed": true, "lang": true}
+
+func init() {
+	opts := dialogueOptions{
+		speakOptions: speakOptions{outputFmt: "mp3_44100_128", play: true, speed: 1.0},
+		gap:          300 * time.Millisecond,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "dialogue <script>",
+		Short: "Render a multi-speaker script (SPEAKER: line) into one audio file",
+		Long: "Reads a script of SPEAKER: line turns (use '-' for stdin). An optional YAML header between --- lines maps speakers to voices and per-speaker speak settings:\n\n" +
+			"  ---\n  voices:\n    HOST: Rachel\n    GUEST: {voice: Roger, stability: 0.5}\n  ---\n  HOST: Welcome back to the show.\n  GUEST: Thanks for having me.\n\n" +
+			"Models that support it (eleven_v3) render the whole scene with ElevenLabs text-to-dialogue. Otherwise, or with --per-line, each line is rendered like speak and joined with --gap silence.",
+		Example: "  sag dialogue episode.txt -o scene.mp3\n" +
+			"  sag dialogue episode.txt --model-id eleven_multilingual_v2 --gap 500ms -o scene.mp3\n" +
+			"  sag dialogue --provider minimax episode.txt",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := applyRateAndSpeed(&opts.speakOptions); err != nil {
+				return err
+			}
+			script, err := readDialogueScript(args[0])
+			if err != nil {
+				return err
+			}
+
+			modelID := script.Model
+			if cmd.Flags().Changed("model-id") {
+				modelID = opts.modelID
+			}
+			spec, err := selectProvider(opts.provider, modelID)
+			if err != nil {
+				return err
+			}
+			if opts.modelID, err = spec.resolveModel(modelID); err != nil {
+				return err
+			}
+			if err := ensureAPIKeyForProvider(spec.name); err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("gap") && script.Gap != "" {
+				if opts.gap, err = time.ParseDuration(script.Gap); err != nil {
+					return fmt.Errorf("header gap: %w", err)
+				}
+			}
+			if opts.gap < 0 {
+				return errors.New("--gap must be >= 0")
+			}
+			opts.lexiconFiles = lexiconFiles(cmd, opts.lexiconFiles)
+			if len(opts.lexiconFiles) > 0 {
+				if opts.localLexicon, err = loadLexiconFiles(opts.lexiconFiles); err != nil {
+					return err
+				}
+			}
+			if spec.name != providerMiniMax {
+				for i := range script.Lines {
+					script.Lines[i].Text = opts.localLexicon.Apply(script.Lines[i].Text)
+				}
+			}
+
+			provider := spec.newProvider()
+			applyOutputPath(cmd, provider, &opts.speakOptions)
+			if err := checkPlayer(opts.play); err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(len(script.Lines))*90*time.Second)
+			defer cancel()
+			voices, err := resolveDialogueVoices(ctx, provider, script)
+			if err != nil {
+				return err
+			}
+
+			chars := 0
+			for _, l := range script.Lines {
+				chars += len([]rune(l.Text))
+			}
+			if spec.name == providerElevenLabs {
+				warnIfOverQuota(ctx, chars)
+			}
+
+			start := time.Now()
+			mode := "per-line"
+			var data []byte
+			if reason := perLineReason(cmd, spec, opts, script, chars); reason == "" {
+				mode = "dialogue"
+				data, err = renderDialogue(ctx, cmd, opts, script, voices)
+			} else {
+				if m, ok := spec.model(opts.modelID); ok && m.Dialogue && !opts.perLine {
+					fmt.Fprintf(os.Stderr, "rendering line by line: %s\n", reason)
+				}
+				data, err = renderDialogueLines(ctx, cmd, provider, opts, script, voices)
+			}
+			if err != nil {
+				return err
+			}
+			n, err := playData(ctx, opts.speakOptions, data)
+			if err != nil {
+				return err
+			}
+			if spec.name == providerElevenLabs {
+				recordQuotaUse(chars)
+			}
+			if opts.metrics {
+				fmt.Fprintf(os.Stderr, "metrics: chars=%d bytes=%d lines=%d speakers=%d model=%s mode=%s dur=%s\n",
+					chars, n, len(script.Lines), len(voices), opts.modelID, mode, time.Since(start).Truncate(time.Millisecond))
+			}
+			return nil
+		},
+	}
+
+	registerVoiceSettingFlags(cmd, &opts.speakOptions)
+	cmd.Flags().StringVar(&opts.provider, "provider", "", "TTS provider: elevenlabs|minimax|openai|local (or SAG_PROVIDER; default inferred from --model-id)")
+	cmd.Flags().StringVar(&opts.modelID, "model-id", "", "Model ID (default: the header's model, else the provider default, eleven_v3 for ElevenLabs)")
+	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "Write audio to file (disables playback unless --play is also set)")
+	cmd.Flags().StringVar(&opts.outputFmt, "format", opts.outputFmt, "Output format (e.g. mp3_44100_128, pcm_44100)")
+	cmd.Flags().BoolVar(&opts.play, "play", opts.play, "Play audio through speakers")
+	cmd.Flags().DurationVar(&opts.gap, "gap", opts.gap, "Silence between lines when rendering line by line (or the header's gap)")
+	cmd.Flags().BoolVar(&opts.perLine, "per-line", false, "Render each line separately even when the model supports text-to-dialogue")
+	cmd.Flags().StringArrayVar(&opts.lexiconFiles, "lexicon-file", nil, "Local pronunciation rules (.yaml or .pls) applied to every line (repeatable; or SAG_LEXICON_FILE)")
+	cmd.Flags().BoolVar(&opts.metrics, "metrics", false, "Print request metrics to stderr")
+	rootCmd.AddCommand(cmd)
+}
+
+func readDialogueScript(path string) (dialogue.Script, error) {
+	if path == "-" {
+		return dialogue.Parse(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return dialogue.Script{}, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	script, err := dialogue.Parse(f)
+	if err != nil {
+		return dialogue.Script{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return script, nil
+}
+
+// resolveDialogueVoices resolves each speaker's voice once.
+func resolveDialogueVoices(ctx context.Context, provider ttsProvider, script dialogue.Script) (map[string]string, error) {
+	voices := map[string]string{}
+	for _, l := range script.Lines {
+		if _, ok := voices[l.Speaker]; ok {
+			continue
+		}
+		id, err := provider.ResolveVoice(ctx, script.Voice(l.Speaker), false)
+		if err != nil {
+			return nil, fmt.Errorf("speaker %s: %w", l.Speaker, err)
+		}
+		voices[l.Speaker] = id
+	}
+	return voices, nil
+}
+
+// perLineReason explains why the script cannot go through text-to-dialogue, or returns "" when it can.
+func perLineReason(cmd *cobra.Command, spec providerSpec, opts dialogueOptions, script dialogue.Script, chars int) string {
+	m, ok := spec.model(opts.modelID)
+	switch {
+	case spec.name != providerElevenLabs || !ok || !m.Dialogue:
+		return opts.modelID + " does not support text-to-dialogue"
+	case opts.perLine:
+		return "--per-line"
+	case script.HasSettings():
+		return "speakers set their own voice settings"
+	case m.MaxChars > 0 && chars > m.MaxChars:
+		return fmt.Sprintf("the script has %d characters; %s renders at most %d per request", chars, m.ID, m.MaxChars)
+	}
+	var unsupported string
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if unsupported == "" && isVoiceSettingFlag(f.Name) && !dialogueSettingFlags[f.Name] {
+			unsupported = "--" + f.Name + " is not supported by text-to-dialogue"
+		}
+	})
+	return unsupported
+}
+
+func isVoiceSettingFlag(name string) bool {
+	probe := &cobra.Command{}
+	registerVoiceSettingFlags(probe, &speakOptions{})
+	return probe.Flags().Lookup(name) != nil
+}
+
+// renderDialogue renders the whole script in one ElevenLabs text-to-dialogue request.
+func renderDialogue(ctx context.Context, cmd *cobra.Command, opts dialogueOptions, script dialogue.Script, voices map[string]string) ([]byte, error) {
+	texts := make([]string, len(script.Lines))
+	inputs := make([]elevenlabs.DialogueInput, len(script.Lines))
+	for i, l := range script.Lines {
+		texts[i] = l.Text
+		inputs[i] = elevenlabs.DialogueInput{Text: l.Text, VoiceID: voices[l.Speaker]}
+	}
+	// Reuse speak's validation of model settings and text; only the fields text-to-dialogue accepts are sent.
+	tts, err := buildTTSRequest(cmd, opts.speakOptions, strings.Join(texts, "\n"))
+	if err != nil {
+		return nil, err
+	}
+	req := elevenlabs.DialogueRequest{
+		Inputs:       inputs,
+		ModelID:      opts.modelID,
+		LanguageCode: tts.LanguageCode,
+		Seed:         tts.Seed,
+		OutputFormat: opts.outputFmt,
+	}
+	if s := tts.VoiceSettings.Stability; s != nil {
+		req.Settings = &elevenlabs.DialogueSettings{Stability: s}
+	}
+	return elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL).TextToDialogue(ctx, req)
+}
+
+// renderDialogueLines renders each line with speak's request builder and joins them with opts.gap of silence.
+// All requests are built first so setting errors surface before any audio is generated.
+func renderDialogueLines(ctx context.Context, cmd *cobra.Command, provider ttsProvider, opts dialogueOptions, script dialogue.Script, voices map[string]string) ([]byte, error) {
+	if len(script.Lines) > 1 && !joinableFormat(opts.outputFmt) {
+		return nil, fmt.Errorf("%d lines cannot be joined as %s audio; use mp3, pcm_<rate>, or wav output", len(script.Lines), opts.outputFmt)
+	}
+	speakers := map[string]*cobra.Command{}
+	speakerOpts := map[string]speakOptions{}
+	for name := range voices {
+		sc, o, err := speakerCommand(cmd, opts.speakOptions, script.Speakers[name].Settings)
+		if err != nil {
+			return nil, fmt.Errorf("speaker %s: %w", name, err)
+		}
+		o.voiceID = voices[name]
+		speakers[name], speakerOpts[name] = sc, o
+	}
+
+	reqs := make([]ttsRequest, len(script.Lines))
+	for i, l := range script.Lines {
+		req, err := provider.BuildRequest(speakers[l.Speaker], speakerOpts[l.Speaker], l.Text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", l.Number, err)
+		}
+		reqs[i] = req
+	}
+
+	parts := make([][]byte, len(reqs))
+	for i, req := range reqs {
+		data, err := provider.Convert(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", script.Lines[i].Number, err)
+		}
+		parts[i] = data
+	}
+	return joinDialogueAudio(opts.outputFmt, parts, opts.gap)
+}
+
+// joinableFormat reports whether rendered lines in format can be joined by joinDialogueAudio.
+func joinableFormat(format string) bool {
+	return concatenableFormat(format) || strings.EqualFold(strings.TrimSpace(format), "wav")
+}
+
+// speakerCommand returns a command carrying the dialogue's voice-setting flags plus the speaker's header
+// settings, so provider request builders see them as if they had been passed on the command line.
+func speakerCommand(parent *cobra.Command, base speakOptions, settings map[string][]string) (*cobra.Command, speakOptions, error) {
+	o := base
+	sc := &cobra.Command{Use: parent.Use}
+	sc.SetContext(parent.Context())
+	registerVoiceSettingFlags(sc, &o)
+	sc.Flags().StringVar(&o.outputFmt, "format", base.outputFmt, "")
+	flags := sc.Flags()
+
+	var err error
+	parent.Flags().Visit(func(f *pflag.Flag) {
+		target := flags.Lookup(f.Name)
+		if target == nil || err != nil {
+			return
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			err = target.Value.(pflag.SliceValue).Replace(slice.GetSlice())
+			target.Changed = true
+			return
+		}
+		err = flags.Set(f.Name, f.Value.String())
+	})
+	if err != nil {
+		return nil, speakOptions{}, err
+	}
+
+	for key, values := range settings {
+		target := flags.Lookup(key)
+		if target == nil || key == "format" {
+			return nil, speakOptions{}, fmt.Errorf("unknown setting %q", key)
+		}
+		if slice, ok := target.Value.(pflag.SliceValue); ok {
+			if err := slice.Replace(values); err != nil {
+				return nil, speakOptions{}, fmt.Errorf("%s: %w", key, err)
+			}
+			target.Changed = true
+			continue
+		}
+		if len(values) != 1 {
+			return nil, speakOptions{}, fmt.Errorf("%s takes a single value", key)
+		}
+		if err := flags.Set(key, values[0]); err != nil {
+			return nil, speakOptions{}, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	// A speaker's own speed wins over a --rate given for the whole script.
+	if _, ok := settings["speed"]; ok {
+		if _, ok := settings["rate"]; !ok {
+			o.rateWPM = 0
+		}
+	}
+	if err := applyRateAndSpeed(&o); err != nil {
+		return nil, speakOptions{}, err
+	}
+	return sc, o, nil
+}
+
+// joinDialogueAudio concatenates rendered lines with gap of silence between them.
+// WAV lines (the local provider, or wav output) are re-wrapped in a single WAV header.
+func joinDialogueAudio(format string, parts [][]byte, gap time.Duration) ([]byte, error) {
+	if len(parts) > 1 {
+		wavs := 0
+		for _, p := range parts {
+			if bytes.HasPrefix(p, []byte("RIFF")) {
+				wavs++
+			}
+		}
+		if wavs == len(parts) {
+			return audio.JoinWAV(parts, gap)
+		}
+		if wavs > 0 || !concatenableFormat(format) {
+			return nil, fmt.Errorf("%d lines cannot be joined as %s audio; use mp3, pcm_<rate>, or wav output", len(parts), format)
+		}
+	}
+	var silence []byte
+	if gap > 0 && len(parts) > 1 {
+		var err error
+		if silence, err = silenceFor(format, parts[0], gap); err != nil {
+			return nil, err
+		}
+	}
+	var out bytes.Buffer
+	for i, p := range parts {
+		if i > 0 {
+			out.Write(silence)
+		}
+		out.Write(p)
+	}
+	return out.Bytes(), nil
+}
+
+// silenceFor returns d of silence in format. MP3 silence copies the frame layout of ref.
+func silenceFor(format string, ref []byte, d time.Duration) ([]byte, error) {
+	format = strings.ToLower(format)
+	if strings.HasPrefix(format, "mp3") {
+		return audio.MP3Silence(ref, d)
+	}
+	encoding, rate, _ := strings.Cut(format, "_")
+	sampleRate, err := strconv.Atoi(rate)
+	if err != nil || sampleRate <= 0 {
+		return nil, fmt.Errorf("--gap needs a sample rate in the format (e.g. pcm_44100), got %s", format)
+	}
+	samples := int(d.Seconds() * float64(sampleRate))
+	switch encoding {
+	case "pcm":
+		return make([]byte, samples*2), nil
+	case "ulaw":
+		return bytes.Repeat([]byte{0xFF}, samples), nil
+	case "alaw":
+		return bytes.Repeat([]byte{0xD5}, samples), nil
+	default:
+		return nil, fmt.Errorf("cannot insert gaps into %s audio", format)
+	}
+}
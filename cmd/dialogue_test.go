@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDialogueScript = `---
+voices:
+  HOST: Rachel
+  GUEST: Roger
+---
+HOST: Welcome back.
+GUEST: Thanks for having me.
+`
+
+// testMP3Frame is one silent MPEG-1 Layer III frame (44.1 kHz, 128 kbps, joint stereo).
+func testMP3Frame() []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x44})
+	return frame
+}
+
+func dialogueTestServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request, body map[string]any)) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/voices"):
+			_, _ = w.Write([]byte(`{"voices":[{"voice_id":"id-rachel","name":"Rachel"},{"voice_id":"id-roger","name":"Roger"}],"has_more":false}`))
+		case r.URL.Path == "/v1/user/subscription":
+			http.NotFound(w, r)
+		default:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode %s: %v", r.URL.Path, err)
+			}
+			handle(w, r, body)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeDialogueScript(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scene.txt")
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDialogueUsesTextToDialogue(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv := dialogueTestServer(t, func(w http.ResponseWriter, r *http.Request, body map[string]any) {
+		if r.URL.Path != "/v1/text-to-dialogue" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		inputs, _ := json.Marshal(body["inputs"])
+		if string(inputs) != `[{"text":"Welcome back.","voice_id":"id-rachel"},{"text":"Thanks for having me.","voice_id":"id-roger"}]` || body["model_id"] != "eleven_v3" {
+			t.Fatalf("unexpected body %v", body)
+		}
+		_, _ = w.Write([]byte("scene"))
+	})
+
+	out := filepath.Join(t.TempDir(), "scene.mp3")
+	if _, err := executeRoot(t, srv.URL, "dialogue", writeDialogueScript(t, testDialogueScript), "-o", out); err != nil {
+		t.Fatalf("dialogue: %v", err)
+	}
+	if data, _ := os.ReadFile(out); string(data) != "scene" {
+		t.Fatalf("unexpected output %q", data)
+	}
+}
+
+func TestDialogueRendersLinesWithGapsAndSpeakerSettings(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	stability := map[string]any{}
+	srv := dialogueTestServer(t, func(w http.ResponseWriter, r *http.Request, body map[string]any) {
+		voice := filepath.Base(r.URL.Path)
+		if !strings.HasPrefix(r.URL.Path, "/v1/text-to-speech/") || body["model_id"] != "eleven_v3" {
+			t.Fatalf("unexpected request %s %v", r.URL.Path, body)
+		}
+		settings, _ := body["voice_settings"].(map[string]any)
+		stability[voice] = settings["stability"]
+		_, _ = w.Write(testMP3Frame())
+	})
+
+	script := strings.Replace(testDialogueScript, "GUEST: Roger", "GUEST: {voice: Roger, stability: 1}", 1)
+	out := filepath.Join(t.TempDir(), "scene.mp3")
+	if _, err := executeRoot(t, srv.URL, "dialogue", writeDialogueScript(t, script), "-o", out, "--gap", "100ms"); err != nil {
+		t.Fatalf("dialogue: %v", err)
+	}
+	if stability["id-rachel"] != nil || stability["id-roger"] != 1.0 {
+		t.Fatalf("speaker settings not applied: %v", stability)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two lines of one frame each, plus 100ms (4 frames of 1152 samples at 44.1kHz) of silence.
+	if len(data) != 6*417 || !bytes.Equal(data[:417], testMP3Frame()) {
+		t.Fatalf("unexpected output length %d", len(data))
+	}
+}
+
+func TestJoinDialogueAudio(t *testing.T) {
+	got, err := joinDialogueAudio("pcm_16000", [][]byte{{1, 1}, {2, 2}}, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+	if len(got) != 4+320 || got[2] != 0 || got[len(got)-1] != 2 {
+		t.Fatalf("unexpected pcm join: %d bytes", len(got))
+	}
+	if _, err := joinDialogueAudio("wav", [][]byte{[]byte("RIFF...."), []byte("RIFF....")}, 0); err == nil {
+		t.Fatalf("expected WAV lines to be rejected")
+	}
+	if _, err := joinDialogueAudio("pcm", [][]byte{{1}, {2}}, time.Second); err == nil {
+		t.Fatalf("expected an error without a sample rate")
+	}
+}
+
+func TestDialogueJoinsLocalWAVLines(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub engine requires a POSIX shell")
+	}
+	// The stub engine answers each line with a 16 kHz mono WAV holding one sample.
+	header := audioWAVHeader(16000, 2)
+	engine := filepath.Join(t.TempDir(), "engine.sh")
+	script := "#!/bin/sh\ncat >/dev/null\nprintf '" + shellOctal(append(header, 7, 0)) + "'\n"
+	if err := os.WriteFile(engine, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SAG_LOCAL_ENGINE", engine)
+	t.Setenv("SAG_LOCAL_MODELS", t.TempDir())
+	dialogueCmd, _, _ := rootCmd.Find([]string{"dialogue"})
+	t.Cleanup(func() {
+		for name, value := range map[string]string{"provider": "", "gap": "300ms"} {
+			f := dialogueCmd.Flags().Lookup(name)
+			_ = f.Value.Set(value)
+			f.Changed = false
+		}
+	})
+
+	out := filepath.Join(t.TempDir(), "scene.wav")
+	if _, err := executeRoot(t, "", "dialogue", "--provider", "local", writeDialogueScript(t, testDialogueScript), "-o", out, "--gap", "1ms"); err != nil {
+		t.Fatalf("dialogue: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two samples around 16 samples of silence, under one header.
+	want := append(append([]byte{7, 0}, make([]byte, 32)...), 7, 0)
+	if !bytes.Equal(data[:4], []byte("RIFF")) || !bytes.Equal(data[44:], want) {
+		t.Fatalf("unexpected joined WAV % x", data)
+	}
+}
+
+func audioWAVHeader(rate, dataSize int) []byte {
+	h := make([]byte, 44)
+	copy(h, "RIFF")
+	binary.LittleEndian.PutUint32(h[4:], uint32(36+dataSize))
+	copy(h[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(h[16:], 16)
+	binary.LittleEndian.PutUint16(h[20:], 1)
+	binary.LittleEndian.PutUint16(h[22:], 1)
+	binary.LittleEndian.PutUint32(h[24:], uint32(rate))
+	binary.LittleEndian.PutUint32(h[28:], uint32(rate*2))
+	binary.LittleEndian.PutUint16(h[32:], 2)
+	binary.LittleEndian.PutUint16(h[34:], 16)
+	copy(h[36:], "data")
+	binary.LittleEndian.PutUint32(h[40:], uint32(dataSize))
+	return h
+}
+
+func shellOctal(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		fmt.Fprintf(&sb, "\\%03o", c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,339 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
+)
+
+const historyPageSize = 100
+
+type historyFilter struct {
+	voice string
+	since string
+	until string
+	limit int
+}
+
+func init() {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Browse past ElevenLabs generations and fetch their audio again",
+		Example: "  sag history list --since 2026-10-13 --until 2026-10-13\n" +
+			"  sag history list --voice Roger --since 7d --json\n" +
+			"  sag history download <id> -o line.mp3",
+	}
+	historyCmd.AddCommand(newHistoryListCmd(), newHistoryShowCmd(), newHistoryDownloadCmd(), newHistoryPlayCmd(), newHistoryDeleteCmd())
+	rootCmd.AddCommand(historyCmd)
+}
+
+func newHistoryListCmd() *cobra.Command {
+	filter := historyFilter{limit: 20}
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List generations, newest first",
+		Args:    cobra.NoArgs,
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
+			items, err := listHistory(ctx, client, filter, time.Now())
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), items)
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			if _, err := fmt.Fprintln(w, "ID\tDATE\tVOICE\tMODEL\tCHARS\tTEXT"); err != nil {
+				return err
+			}
+			for _, it := range items {
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", it.HistoryItemID, it.Date().Local().Format(time.DateTime), it.VoiceName, it.ModelID, it.Characters(), truncate(it.Text, 50)); err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		},
+	}
+	cmd.Flags().StringVar(&filter.voice, "voice", "", "Only generations with this voice (name or ID)")
+	cmd.Flags().StringVar(&filter.since, "since", "", "Only generations on or after this time (YYYY-MM-DD, RFC 3339, today, yesterday, or an age like 36h/7d)")
+	cmd.Flags().StringVar(&filter.until, "until", "", "Only generations before this time; a plain date includes that whole day")
+	cmd.Flags().IntVar(&filter.limit, "limit", filter.limit, "Maximum number of items (0 = no limit)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print items as JSON")
+	return cmd
+}
+
+func newHistoryShowCmd() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:     "show <id>",
+		Short:   "Show the text and settings of a generation",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			item, err := client.GetHistoryItem(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), item)
+			}
+			return printHistoryItem(cmd.OutOrStdout(), item)
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the item as JSON")
+	return cmd
+}
+
+func newHistoryDownloadCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:     "download <id>",
+		Short:   "Save the audio of a generation",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
+			if output == "" {
+				item, err := client.GetHistoryItem(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				output = args[0] + "." + historyAudioExt(item.ContentType)
+			}
+			data, err := client.HistoryAudio(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := playData(ctx, speakOptions{outputPath: output}, data); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote %s (%d bytes)\n", output, len(data))
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write audio to file (default: <id>.<ext>)")
+	return cmd
+}
+
+func newHistoryPlayCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "play <id>",
+		Short:   "Play the audio of a generation",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkPlayer(true); err != nil {
+				return err
+			}
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+			defer cancel()
+			data, err := client.HistoryAudio(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			_, err = playData(ctx, speakOptions{play: true}, data)
+			return err
+		},
+	}
+}
+
+func newHistoryDeleteCmd() *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:     "delete <id>",
+		Short:   "Delete a generation and its audio (asks for confirmation)",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			if !yes {
+				item, err := client.GetHistoryItem(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				ok, err := confirm(fmt.Sprintf("Delete history item %s (%q)? This cannot be undone. [y/N] ", args[0], truncate(item.Text, 40)))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return errors.New("aborted")
+				}
+			}
+			if err := client.DeleteHistoryItem(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted history item %s\n", args[0])
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	return cmd
+}
+
+// listHistory pages through the history (newest first) until the filter's limit or --since is reached.
+func listHistory(ctx context.Context, client *elevenlabs.Client, filter historyFilter, now time.Time) ([]elevenlabs.HistoryItem, error) {
+	var since, until time.Time
+	var err error
+	if filter.since != "" {
+		if since, err = parseHistoryTime(filter.since, now, false); err != nil {
+			return nil, fmt.Errorf("--since: %w", err)
+		}
+	}
+	if filter.until != "" {
+		if until, err = parseHistoryTime(filter.until, now, true); err != nil {
+			return nil, fmt.Errorf("--until: %w", err)
+		}
+	}
+	if filter.limit < 0 {
+		return nil, errors.New("--limit must be >= 0")
+	}
+
+	q := elevenlabs.HistoryQuery{PageSize: historyPageSize}
+	if filter.voice != "" {
+		if q.VoiceID, err = resolveVoice(ctx, client, filter.voice, false); err != nil {
+			return nil, err
+		}
+	}
+
+	var items []elevenlabs.HistoryItem
+	for {
+		page, err := client.ListHistory(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range page.Items {
+			date := it.Date()
+			if !until.IsZero() && !date.Before(until) {
+				continue
+			}
+			if !since.IsZero() && date.Before(since) {
+				return items, nil
+			}
+			items = append(items, it)
+			if filter.limit > 0 && len(items) >= filter.limit {
+				return items, nil
+			}
+		}
+		if !page.HasMore || page.LastID == "" {
+			return items, nil
+		}
+		q.StartAfter = page.LastID
+	}
+}
+
+// parseHistoryTime accepts a date, an RFC 3339 timestamp, today/yesterday, or an age such as 36h or 7d.
+// With endOfDay, whole-day values refer to the end of that day so --until includes it.
+func parseHistoryTime(s string, now time.Time, endOfDay bool) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	day := func(t time.Time) time.Time {
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		if endOfDay {
+			return start.AddDate(0, 0, 1)
+		}
+		return start
+	}
+	switch s {
+	case "today":
+		return day(now), nil
+	case "yesterday":
+		return day(now.AddDate(0, 0, -1)), nil
+	}
+	if n, ok := strings.CutSuffix(s, "d"); ok {
+		if days, err := strconv.Atoi(n); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return day(t), nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q (use YYYY-MM-DD, RFC 3339, today, yesterday, or an age like 36h/7d)", s)
+}
+
+func printHistoryItem(w io.Writer, it elevenlabs.HistoryItem) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := [][2]string{
+		{"id", it.HistoryItemID},
+		{"date", it.Date().Local().Format(time.DateTime)},
+		{"voice", fmt.Sprintf("%s (%s)", it.VoiceName, it.VoiceID)},
+		{"model", it.ModelID},
+		{"chars", strconv.Itoa(it.Characters())},
+		{"format", it.ContentType},
+		{"state", it.State},
+	}
+	if it.Source != "" {
+		rows = append(rows, [2]string{"source", it.Source})
+	}
+	if len(it.Settings) > 0 {
+		settings, err := json.Marshal(it.Settings)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, [2]string{"settings", string(settings)})
+	}
+	for _, r := range rows {
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", r[0], r[1]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", it.Text)
+	return err
+}
+
+func historyAudioExt(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "wav"):
+		return "wav"
+	case strings.Contains(contentType, "ogg"), strings.Contains(contentType, "opus"):
+		return "ogg"
+	case strings.Contains(contentType, "pcm"):
+		return "pcm"
+	default:
+		return "mp3"
+	}
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
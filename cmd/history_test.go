@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+)
+
+func TestListHistoryFiltersByDateAcrossPages(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	day := func(d int) int64 { return time.Date(2026, 10, d, 9, 0, 0, 0, time.Local).Unix() }
+	pages := map[string][]elevenlabs.HistoryItem{
+		"":    {{HistoryItemID: "h16", DateUnix: day(16)}, {HistoryItemID: "h14", DateUnix: day(14)}},
+		"h14": {{HistoryItemID: "h13", DateUnix: day(13)}, {HistoryItemID: "h12", DateUnix: day(12)}},
+	}
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		after := r.URL.Query().Get("start_after_history_item_id")
+		items := pages[after]
+		_ = json.NewEncoder(w).Encode(elevenlabs.HistoryPage{Items: items, LastID: items[len(items)-1].HistoryItemID, HasMore: after == ""})
+	}))
+	defer srv.Close()
+
+	client := elevenlabs.NewClient("key", srv.URL)
+	items, err := listHistory(t.Context(), client, historyFilter{since: "2026-10-13", until: "2026-10-14"}, now)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(items) != 2 || items[0].HistoryItemID != "h14" || items[1].HistoryItemID != "h13" || requests != 2 {
+		t.Fatalf("unexpected items %+v after %d requests", items, requests)
+	}
+}
+
+func TestParseHistoryTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in       string
+		endOfDay bool
+		want     time.Time
+	}{
+		{"2026-10-13", false, time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)},
+		{"2026-10-13", true, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)},
+		{"yesterday", false, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{"7d", false, now.AddDate(0, 0, -7)},
+		{"36h", true, now.Add(-36 * time.Hour)},
+		{"2026-10-01T08:30:00Z", false, time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		got, err := parseHistoryTime(tc.in, now, tc.endOfDay)
+		if err != nil || !got.Equal(tc.want) {
+			t.Fatalf("parseHistoryTime(%q, %v) = %v, %v; want %v", tc.in, tc.endOfDay, got, err, tc.want)
+		}
+	}
+	if _, err := parseHistoryTime("last tuesday", now, false); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestHistoryDownloadNamesFileFromContentType(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/history/h1":
+			_, _ = w.Write([]byte(`{"history_item_id":"h1","content_type":"audio/wav"}`))
+		case "/v1/history/h1/audio":
+			_, _ = w.Write([]byte("RIFF"))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	if _, err := executeRoot(t, srv.URL, "history", "download", "h1"); err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "h1.wav"))
+	if err != nil || string(data) != "RIFF" {
+		t.Fatalf("unexpected file %q, %v", data, err)
+	}
+}
@@ -0,0 +1,398 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/steipete/sag/internal/minimax"
+
+	"github.com/spf13/cobra"
+)
+
+const jobsFileName = "jobs.json"
+
+// jobRecord is a submitted long-text job, stored locally so it can be resumed later.
+type jobRecord struct {
+	TaskID    int64     `json:"task_id"`
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	VoiceID   string    `json:"voice_id"`
+	Chars     int       `json:"chars"`
+	Format    string    `json:"format,omitempty"`
+	Output    string    `json:"output,omitempty"`
+	Status    string    `json:"status"`
+	FileID    int64     `json:"file_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type jobStore struct {
+	Version int         `json:"version"`
+	Jobs    []jobRecord `json:"jobs"`
+}
+
+func jobStorePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil || dir == "" {
+		home, homeErr := os.UserHomeDir()
+		if homeErr != nil || home == "" {
+			return "", errors.New("no config directory available")
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "sag", jobsFileName), nil
+}
+
+func loadJobStore(path string) (*jobStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &jobStore{Version: 1}, nil
+		}
+		return nil, err
+	}
+	store := &jobStore{Version: 1}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return store, nil
+}
+
+func saveJobStore(path string, store *jobStore) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (s *jobStore) find(taskID int64) (jobRecord, bool) {
+	for _, j := range s.Jobs {
+		if j.TaskID == taskID {
+			return j, true
+		}
+	}
+	return jobRecord{}, false
+}
+
+func (s *jobStore) upsert(rec jobRecord) {
+	rec.UpdatedAt = time.Now().UTC()
+	for i, j := range s.Jobs {
+		if j.TaskID == rec.TaskID {
+			s.Jobs[i] = rec
+			return
+		}
+	}
+	s.Jobs = append(s.Jobs, rec)
+}
+
+// recordJob adds or updates a job in the local store.
+func recordJob(rec jobRecord) error {
+	path, err := jobStorePath()
+	if err != nil {
+		return err
+	}
+	store, err := loadJobStore(path)
+	if err != nil {
+		return err
+	}
+	store.upsert(rec)
+	return saveJobStore(path, store)
+}
+
+// runAsync submits text as a server-side job and records it instead of playing audio.
+func runAsync(cmd *cobra.Command, provider ttsProvider, opts speakOptions, text string) error {
+	submitter, ok := provider.(asyncProvider)
+	if !ok {
+		return fmt.Errorf("--async is not supported by %s (use --provider minimax)", provider.Name())
+	}
+	if opts.wantsTimings() {
+		return errors.New("--subtitles/--alignment are not supported with --async")
+	}
+	opts.stream, opts.play = false, false
+
+	req, err := provider.BuildRequest(cmd, opts, text)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+	defer cancel()
+	rec, err := submitter.SubmitAsync(ctx, req)
+	if err != nil {
+		return err
+	}
+	rec.Chars = len([]rune(text))
+	rec.Format = opts.outputFmt
+	if opts.outputPath != "" {
+		if rec.Output, err = filepath.Abs(opts.outputPath); err != nil {
+			return err
+		}
+	}
+	rec.CreatedAt = time.Now().UTC()
+	if err := recordJob(rec); err != nil {
+		return err
+	}
+	fmt.Printf("submitted %s task %d (%d chars); run `sag jobs wait %d` to fetch the audio\n", rec.Provider, rec.TaskID, rec.Chars, rec.TaskID)
+	return nil
+}
+
+func init() {
+	jobsCmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Manage MiniMax long-text jobs submitted with `speak --async`",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List locally recorded jobs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path, err := jobStorePath()
+			if err != nil {
+				return err
+			}
+			store, err := loadJobStore(path)
+			if err != nil {
+				return err
+			}
+			jobs := append([]jobRecord(nil), store.Jobs...)
+			sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			if _, err := fmt.Fprintln(w, "TASK ID\tSTATUS\tMODEL\tVOICE\tCHARS\tCREATED\tOUTPUT"); err != nil {
+				return err
+			}
+			for _, j := range jobs {
+				if _, err := fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%s\n", j.TaskID, j.Status, j.Model, j.VoiceID, j.Chars, j.CreatedAt.Local().Format(time.DateTime), j.Output); err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		},
+	}
+
+	statusCmd := &cobra.Command{
+		Use:     "status <task-id>",
+		Short:   "Query the current status of a job",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureMiniMaxAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskID, err := parseTaskID(args[0])
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			rec, err := refreshJob(ctx, newJobsClient(), taskID)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "task %d: %s\n", rec.TaskID, rec.Status)
+			return nil
+		},
+	}
+
+	var waitOutput string
+	var waitInterval, waitTimeout time.Duration
+	waitCmd := &cobra.Command{
+		Use:     "wait <task-id>",
+		Short:   "Poll a job until it finishes, then download the audio",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureMiniMaxAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskID, err := parseTaskID(args[0])
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), waitTimeout)
+			defer cancel()
+			client := newJobsClient()
+			rec, err := waitForJob(ctx, client, taskID, waitInterval)
+			if err != nil {
+				return err
+			}
+			return fetchJob(ctx, client, rec, waitOutput, cmd.OutOrStdout())
+		},
+	}
+	waitCmd.Flags().StringVarP(&waitOutput, "output", "o", "", "Write audio to file (default: the -o given at submit time, or minimax-<task-id>.<format>)")
+	waitCmd.Flags().DurationVar(&waitInterval, "interval", 5*time.Second, "Polling interval")
+	waitCmd.Flags().DurationVar(&waitTimeout, "timeout", 2*time.Hour, "Give up after this long")
+
+	var fetchOutput string
+	fetchCmd := &cobra.Command{
+		Use:     "fetch <task-id>",
+		Short:   "Download the audio of a finished job",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureMiniMaxAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskID, err := parseTaskID(args[0])
+			if err != nil {
+				return err
+			}
+			client := newJobsClient()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			rec, err := refreshJob(ctx, client, taskID)
+			cancel()
+			if err != nil {
+				return err
+			}
+			if rec.Status != minimax.AsyncStatusSuccess {
+				return fmt.Errorf("task %d is %s; run `sag jobs wait %d`", rec.TaskID, rec.Status, rec.TaskID)
+			}
+			return fetchJob(cmd.Context(), client, rec, fetchOutput, cmd.OutOrStdout())
+		},
+	}
+	fetchCmd.Flags().StringVarP(&fetchOutput, "output", "o", "", "Write audio to file (default: the -o given at submit time, or minimax-<task-id>.<format>)")
+
+	jobsCmd.AddCommand(listCmd, statusCmd, waitCmd, fetchCmd)
+	rootCmd.AddCommand(jobsCmd)
+}
+
+func newJobsClient() *minimax.Client {
+	return minimax.NewClient(cfg.APIKey, minimaxBaseURL())
+}
+
+func parseTaskID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid task id %q", s)
+	}
+	return id, nil
+}
+
+// refreshJob queries a job and stores its latest status. Jobs submitted elsewhere are recorded too.
+func refreshJob(ctx context.Context, client *minimax.Client, taskID int64) (jobRecord, error) {
+	task, err := client.QueryAsyncTTS(ctx, taskID)
+	if err != nil {
+		return jobRecord{}, err
+	}
+	path, err := jobStorePath()
+	if err != nil {
+		return jobRecord{}, err
+	}
+	store, err := loadJobStore(path)
+	if err != nil {
+		return jobRecord{}, err
+	}
+	rec, ok := store.find(taskID)
+	if !ok {
+		rec = jobRecord{TaskID: taskID, Provider: providerMiniMax, CreatedAt: time.Now().UTC()}
+	}
+	rec.Status = task.Status
+	if task.FileID != 0 {
+		rec.FileID = task.FileID
+	}
+	store.upsert(rec)
+	if err := saveJobStore(path, store); err != nil {
+		return jobRecord{}, err
+	}
+	return rec, nil
+}
+
+func waitForJob(ctx context.Context, client *minimax.Client, taskID int64, interval time.Duration) (jobRecord, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	last := ""
+	for {
+		rec, err := refreshJob(ctx, client, taskID)
+		if err != nil {
+			return jobRecord{}, err
+		}
+		if rec.Status != last {
+			fmt.Fprintf(os.Stderr, "task %d: %s\n", taskID, rec.Status)
+			last = rec.Status
+		}
+		switch rec.Status {
+		case minimax.AsyncStatusSuccess:
+			return rec, nil
+		case minimax.AsyncStatusFailed, minimax.AsyncStatusExpired:
+			return rec, fmt.Errorf("task %d %s", taskID, strings.ToLower(rec.Status))
+		}
+		select {
+		case <-ctx.Done():
+			return rec, fmt.Errorf("task %d still %s: %w", taskID, rec.Status, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+func fetchJob(ctx context.Context, client *minimax.Client, rec jobRecord, output string, stdout io.Writer) error {
+	if rec.FileID == 0 {
+		return fmt.Errorf("task %d has no result file yet", rec.TaskID)
+	}
+	if output == "" {
+		output = rec.Output
+	}
+	if output == "" {
+		format := rec.Format
+		if format == "" || strings.Contains(format, "_") {
+			format = "mp3"
+		}
+		output = fmt.Sprintf("minimax-%d.%s", rec.TaskID, format)
+	}
+	body, err := client.DownloadFile(ctx, rec.FileID)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = body.Close() }()
+	if err := writeJobAudio(body, output); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "wrote %s\n", output)
+	return err
+}
+
+// writeJobAudio saves a job result. MiniMax may deliver a tar bundle (audio plus subtitles/metadata);
+// in that case the first audio entry is extracted.
+func writeJobAudio(r io.Reader, path string) error {
+	br := bufio.NewReaderSize(r, 1024)
+	head, _ := br.Peek(262)
+	var src io.Reader = br
+	if len(head) == 262 && string(head[257:262]) == "ustar" {
+		tr := tar.NewReader(br)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				return errors.New("job result archive contains no audio file")
+			}
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(filepath.Ext(hdr.Name)) {
+			case ".mp3", ".wav", ".flac", ".pcm":
+				src = tr
+			default:
+				continue
+			}
+			break
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steipete/sag/internal/minimax"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunAsyncRecordsJob(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/t2a_async_v2" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"task_id":42,"base_resp":{"status_code":0,"status_msg":"success"}}`))
+	}))
+	defer srv.Close()
+
+	provider := newMiniMaxProvider(minimax.NewClient("key", srv.URL))
+	opts := speakOptions{modelID: "speech-02-hd", voiceID: "v1", outputFmt: "mp3", outputPath: "book.mp3", play: true, stream: true, async: true}
+	cmd := &cobra.Command{}
+	cmd.SetContext(t.Context())
+	if err := runAsync(cmd, provider, opts, "Once upon a time."); err != nil {
+		t.Fatalf("runAsync: %v", err)
+	}
+	if got["text"] != "Once upon a time." || got["model"] != "speech-02-hd" {
+		t.Fatalf("unexpected payload %v", got)
+	}
+
+	path, err := jobStorePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := loadJobStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, ok := store.find(42)
+	if !ok {
+		t.Fatalf("job not recorded: %+v", store)
+	}
+	if rec.Status != minimax.AsyncStatusProcessing || rec.VoiceID != "v1" || rec.Chars != 17 || !filepath.IsAbs(rec.Output) {
+		t.Fatalf("unexpected record %+v", rec)
+	}
+}
+
+func TestRunAsyncRejectsUnsupportedProvider(t *testing.T) {
+	spec, err := lookupProvider(providerOpenAI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runAsync(&cobra.Command{}, spec.newProvider(), speakOptions{}, "hi"); err == nil {
+		t.Fatal("expected error for provider without async jobs")
+	}
+}
+
+func TestWaitForJobFetchesArchivedAudio(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for name, body := range map[string]string{"42.json": `{}`, "42.mp3": "ID3audio"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/query/t2a_async_query_v2":
+			if r.URL.Query().Get("task_id") != "42" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			if polls.Add(1) < 2 {
+				_, _ = w.Write([]byte(`{"task_id":42,"status":"Processing","base_resp":{"status_code":0}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"task_id":42,"status":"Success","file_id":7,"base_resp":{"status_code":0}}`))
+		case "/v1/files/retrieve_content":
+			if r.URL.Query().Get("file_id") != "7" {
+				t.Fatalf("unexpected query %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write(archive.Bytes())
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := minimax.NewClient("key", srv.URL)
+	rec, err := waitForJob(t.Context(), client, 42, time.Millisecond)
+	if err != nil {
+		t.Fatalf("waitForJob: %v", err)
+	}
+	if rec.FileID != 7 || polls.Load() != 2 {
+		t.Fatalf("unexpected record %+v after %d polls", rec, polls.Load())
+	}
+
+	out := filepath.Join(t.TempDir(), "book.mp3")
+	var stdout bytes.Buffer
+	if err := fetchJob(t.Context(), client, rec, out, &stdout); err != nil {
+		t.Fatalf("fetchJob: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "ID3audio" {
+		t.Fatalf("unexpected audio %q", data)
+	}
+}
+
+func TestWaitForJobFailed(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"task_id":9,"status":"Failed","base_resp":{"status_code":0}}`))
+	}))
+	defer srv.Close()
+
+	if _, err := waitForJob(t.Context(), minimax.NewClient("key", srv.URL), 9, time.Millisecond); err == nil {
+		t.Fatal("expected error for failed task")
+	}
+}
+
+func TestWriteJobAudioRaw(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "raw.mp3")
+	if err := writeJobAudio(bytes.NewReader([]byte("ID3")), out); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil || string(data) != "ID3" {
+		t.Fatalf("unexpected output %q, %v", data, err)
+	}
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+	"github.com/steipete/sag/internal/lexicon"
+
+	"github.com/spf13/cobra"
+)
+
+type lexiconRuleFlags struct {
+	aliases  []string
+	phonemes []string
+	alphabet string
+}
+
+func (f *lexiconRuleFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.aliases, "alias", nil, "Alias rule WORD=REPLACEMENT, spoken as written (repeatable)")
+	cmd.Flags().StringArrayVar(&f.phonemes, "phoneme", nil, "Phoneme rule WORD=PHONEMES in --alphabet (repeatable; eleven_flash_v2/eleven_turbo_v2/eleven_monolingual_v1 only)")
+	cmd.Flags().StringVar(&f.alphabet, "alphabet", elevenlabs.AlphabetIPA, "Phoneme alphabet: ipa|cmu-arpabet")
+}
+
+func (f lexiconRuleFlags) rules() ([]elevenlabs.PronunciationRule, error) {
+	alphabet := strings.ToLower(strings.TrimSpace(f.alphabet))
+	if alphabet == "cmu" {
+		alphabet = elevenlabs.AlphabetCMU
+	}
+	if alphabet != elevenlabs.AlphabetIPA && alphabet != elevenlabs.AlphabetCMU {
+		return nil, errors.New("alphabet must be ipa or cmu-arpabet")
+	}
+	rules := make([]elevenlabs.PronunciationRule, 0, len(f.aliases)+len(f.phonemes))
+	for _, raw := range f.aliases {
+		word, alias, err := splitLexiconRule(raw, "--alias")
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, elevenlabs.PronunciationRule{StringToReplace: word, Type: elevenlabs.RuleAlias, Alias: alias})
+	}
+	for _, raw := range f.phonemes {
+		word, phoneme, err := splitLexiconRule(raw, "--phoneme")
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, elevenlabs.PronunciationRule{StringToReplace: word, Type: elevenlabs.RulePhoneme, Phoneme: strings.Trim(phoneme, "/"), Alphabet: alphabet})
+	}
+	return rules, nil
+}
+
+func splitLexiconRule(raw, flag string) (string, string, error) {
+	word, value, ok := strings.Cut(raw, "=")
+	word, value = strings.TrimSpace(word), strings.TrimSpace(value)
+	if !ok || word == "" || value == "" {
+		return "", "", fmt.Errorf("invalid %s %q (expected WORD=VALUE)", flag, raw)
+	}
+	return word, value, nil
+}
+
+func init() {
+	lexiconCmd := &cobra.Command{
+		Use:   "lexicon",
+		Short: "Manage ElevenLabs pronunciation dictionaries (use with speak --lexicon)",
+		Example: "  sag lexicon create products --alias \"sag=sagg\" --alias \"MiniMax=mini max\"\n" +
+			"  sag lexicon add-rule products --phoneme \"tomato=təˈmeɪtoʊ\"\n" +
+			"  sag lexicon create brands --file brands.pls\n" +
+			"  sag speak --lexicon products \"Try sag today\"",
+	}
+	lexiconCmd.AddCommand(newLexiconCreateCmd(), newLexiconAddRuleCmd(), newLexiconListCmd(), newLexiconRemoveCmd())
+	rootCmd.AddCommand(lexiconCmd)
+}
+
+func newLexiconCreateCmd() *cobra.Command {
+	var ruleFlags lexiconRuleFlags
+	var file, description string
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Create a dictionary from rules or a .pls lexicon file",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			rules, err := ruleFlags.rules()
+			if err != nil {
+				return err
+			}
+			if file != "" && len(rules) > 0 {
+				return errors.New("choose either --file or --alias/--phoneme rules")
+			}
+			if file == "" && len(rules) == 0 {
+				return errors.New("add at least one --alias or --phoneme rule, or upload a --file")
+			}
+
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+			var version elevenlabs.PronunciationDictionaryVersion
+			if file != "" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = f.Close()
+				}()
+				version, err = client.UploadPronunciationDictionary(ctx, name, description, filepath.Base(file), f)
+				if err != nil {
+					return err
+				}
+			} else if version, err = client.CreatePronunciationDictionary(ctx, name, description, rules); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "created lexicon %s (%s@%s)\n", name, version.ID, version.VersionID)
+			return nil
+		},
+	}
+	ruleFlags.register(cmd)
+	cmd.Flags().StringVar(&file, "file", "", "Upload a PLS lexicon file instead of rules")
+	cmd.Flags().StringVar(&description, "description", "", "Dictionary description")
+	return cmd
+}
+
+func newLexiconAddRuleCmd() *cobra.Command {
+	var ruleFlags lexiconRuleFlags
+	cmd := &cobra.Command{
+		Use:     "add-rule <lexicon>",
+		Short:   "Add or replace rules in a dictionary (creates a new version)",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rules, err := ruleFlags.rules()
+			if err != nil {
+				return err
+			}
+			if len(rules) == 0 {
+				return errors.New("add at least one --alias or --phoneme rule")
+			}
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+			dict, err := findLexicon(ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			version, err := client.AddPronunciationRules(ctx, dict.ID, rules)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "lexicon %s is now at version %s\n", dict.Name, version.VersionID)
+			return nil
+		},
+	}
+	ruleFlags.register(cmd)
+	return cmd
+}
+
+func newLexiconListCmd() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List pronunciation dictionaries",
+		Args:    cobra.NoArgs,
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			dicts, err := client.ListPronunciationDictionaries(ctx)
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), dicts)
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			if _, err := fmt.Fprintln(w, "NAME\tID\tLATEST VERSION\tDESCRIPTION"); err != nil {
+				return err
+			}
+			for _, d := range dicts {
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Name, d.ID, d.LatestVersionID, truncate(d.Description, 50)); err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print dictionaries as JSON")
+	return cmd
+}
+
+func newLexiconRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <lexicon> <word>...",
+		Short:   "Remove the rules for words from a dictionary (creates a new version)",
+		Args:    cobra.MinimumNArgs(2),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+			dict, err := findLexicon(ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			version, err := client.RemovePronunciationRules(ctx, dict.ID, args[1:])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "lexicon %s is now at version %s\n", dict.Name, version.VersionID)
+			return nil
+		},
+	}
+}
+
+// findLexicon matches a dictionary by ID or (case-insensitive) name.
+func findLexicon(ctx context.Context, client *elevenlabs.Client, ref string) (elevenlabs.PronunciationDictionary, error) {
+	dicts, err := client.ListPronunciationDictionaries(ctx)
+	if err != nil {
+		return elevenlabs.PronunciationDictionary{}, err
+	}
+	return matchLexicon(dicts, ref)
+}
+
+func matchLexicon(dicts []elevenlabs.PronunciationDictionary, ref string) (elevenlabs.PronunciationDictionary, error) {
+	ref = strings.TrimSpace(ref)
+	var matches []elevenlabs.PronunciationDictionary
+	for _, d := range dicts {
+		if d.ID == ref {
+			return d, nil
+		}
+		if strings.EqualFold(d.Name, ref) {
+			matches = append(matches, d)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return elevenlabs.PronunciationDictionary{}, fmt.Errorf("lexicon %q not found; run `sag lexicon list`", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, m := range matches {
+			ids[i] = m.ID
+		}
+		return elevenlabs.PronunciationDictionary{}, fmt.Errorf("lexicon name %q is ambiguous; use an ID: %s", ref, strings.Join(ids, ", "))
+	}
+}
+
+// resolveLexiconLocators turns --lexicon name[@version] values into request locators.
+// Without a version, the dictionary's latest version is used.
+func resolveLexiconLocators(ctx context.Context, client *elevenlabs.Client, refs []string) ([]elevenlabs.PronunciationDictionaryLocator, error) {
+	if len(refs) > elevenlabs.MaxPronunciationDictionaries {
+		return nil, fmt.Errorf("at most %d --lexicon values per request", elevenlabs.MaxPronunciationDictionaries)
+	}
+	dicts, err := client.ListPronunciationDictionaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locators := make([]elevenlabs.PronunciationDictionaryLocator, 0, len(refs))
+	for _, raw := range refs {
+		ref, version, _ := strings.Cut(raw, "@")
+		dict, err := matchLexicon(dicts, ref)
+		if err != nil {
+			return nil, err
+		}
+		if version = strings.TrimSpace(version); version == "" {
+			version = dict.LatestVersionID
+		}
+		locators = append(locators, elevenlabs.PronunciationDictionaryLocator{PronunciationDictionaryID: dict.ID, VersionID: version})
+	}
+	return locators, nil
+}
+
+// lexiconFiles returns the --lexicon-file paths of speak or dialogue, falling back to the SAG_LEXICON_FILE
+// path list when the flag is not set.
+func lexiconFiles(cmd *cobra.Command, files []string) []string {
+	if cmd.Flags().Changed("lexicon-file") {
+		return files
+	}
+	return filepath.SplitList(os.Getenv("SAG_LEXICON_FILE"))
+}
+
+// loadLexiconFiles loads --lexicon-file paths into one lexicon; rules apply in file order.
+func loadLexiconFiles(paths []string) (*lexicon.Lexicon, error) {
+	var out lexicon.Lexicon
+	for _, p := range paths {
+		lex, err := lexicon.Load(p)
+		if err != nil {
+			return nil, fmt.Errorf("load lexicon: %w", err)
+		}
+		out.Merge(lex)
+	}
+	return &out, nil
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+	"github.com/steipete/sag/internal/lexicon"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const testLexicons = `{"pronunciation_dictionaries":[
+	{"id":"d1","name":"products","latest_version_id":"v3"},
+	{"id":"d2","name":"brands","latest_version_id":"v7"},
+	{"id":"d3","name":"dup","latest_version_id":"v1"},
+	{"id":"d4","name":"Dup","latest_version_id":"v1"}],"has_more":false}`
+
+func TestLexiconRuleFlags(t *testing.T) {
+	f := lexiconRuleFlags{aliases: []string{"sag = sagg"}, phonemes: []string{"tomato=/təˈmeɪtoʊ/"}, alphabet: "IPA"}
+	rules, err := f.rules()
+	if err != nil {
+		t.Fatalf("rules: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Alias != "sagg" || rules[1].Phoneme != "təˈmeɪtoʊ" || rules[1].Alphabet != elevenlabs.AlphabetIPA {
+		t.Fatalf("unexpected rules %+v", rules)
+	}
+	for _, bad := range []lexiconRuleFlags{{aliases: []string{"sag"}, alphabet: "ipa"}, {phonemes: []string{"a=b"}, alphabet: "sampa"}} {
+		if _, err := bad.rules(); err == nil {
+			t.Fatalf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestResolveLexiconLocators(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testLexicons))
+	}))
+	defer srv.Close()
+	client := elevenlabs.NewClient("key", srv.URL)
+
+	got, err := resolveLexiconLocators(t.Context(), client, []string{"Products", "d2@v5"})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	want := []elevenlabs.PronunciationDictionaryLocator{{PronunciationDictionaryID: "d1", VersionID: "v3"}, {PronunciationDictionaryID: "d2", VersionID: "v5"}}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for _, refs := range [][]string{{"missing"}, {"dup"}, {"a", "b", "c", "d"}} {
+		if _, err := resolveLexiconLocators(t.Context(), client, refs); err == nil {
+			t.Fatalf("expected error for %v", refs)
+		}
+	}
+}
+
+func TestElevenLabsBuildRequestResolvesLexiconsOnce(t *testing.T) {
+	var lists int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		_, _ = w.Write([]byte(testLexicons))
+	}))
+	defer srv.Close()
+
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+	cmd := &cobra.Command{}
+	cmd.SetContext(t.Context())
+	opts := speakOptions{modelID: "eleven_multilingual_v2", voiceID: "v", speed: 1, lexicons: []string{"products"}}
+	for range 2 {
+		req, err := provider.BuildRequest(cmd, opts, "Try sag")
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		body, _ := json.Marshal(req.payload)
+		if !strings.Contains(string(body), `"pronunciation_dictionary_locators":[{"pronunciation_dictionary_id":"d1","version_id":"v3"}]`) {
+			t.Fatalf("missing locators: %s", body)
+		}
+	}
+	if lists != 1 {
+		t.Fatalf("expected one dictionary lookup, got %d", lists)
+	}
+}
+
+func TestLexiconCreateCommand(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/pronunciation-dictionaries/add-from-rules" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"id":"d9","name":"products","version_id":"v1"}`))
+	}))
+	defer srv.Close()
+
+	out, err := executeRoot(t, srv.URL, "lexicon", "create", "products", "--alias", "sag=sagg")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.Contains(out, "d9@v1") || got["name"] != "products" {
+		t.Fatalf("unexpected output %q / body %v", out, got)
+	}
+}
+
+func TestLocalLexiconMiniMaxTonesAndRewrite(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "team.yaml")
+	if err := os.WriteFile(yamlPath, []byte("rules:\n  - match: sag\n    replace: sagg\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plsPath := filepath.Join(dir, "brands.pls")
+	pls := `<lexicon version="1.0" xmlns="http://www.w3.org/2005/01/pronunciation-lexicon"><lexeme><grapheme>MiniMax</grapheme><alias>mini max</alias></lexeme></lexicon>`
+	if err := os.WriteFile(plsPath, []byte(pls), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lex, err := loadLexiconFiles([]string{yamlPath, plsPath})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := lex.Apply("Try Sag with MiniMax"); got != "Try sagg with mini max" {
+		t.Fatalf("rewrite = %q", got)
+	}
+
+	cmd := &cobra.Command{}
+	opts := speakOptions{modelID: "speech-02-hd", voiceID: "v", speed: 1, localLexicon: lex}
+	req, err := buildMiniMaxTTSRequest(cmd, opts, "Try sag with MiniMax")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if req.Text != "Try sag with MiniMax" {
+		t.Fatalf("MiniMax text should be untouched, got %q", req.Text)
+	}
+	if req.PronunciationDict == nil || strings.Join(req.PronunciationDict.Tone, ",") != "sag/sagg,MiniMax/mini max" {
+		t.Fatalf("unexpected tones: %+v", req.PronunciationDict)
+	}
+
+	if _, err := loadLexiconFiles([]string{filepath.Join(dir, "missing.yaml")}); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
+
+func TestMiniMaxWarnsOnSlashLexiconRules(t *testing.T) {
+	lex, err := lexicon.ParseYAML([]byte("rules:\n  - match: TCP/IP\n    replace: tee see pee eye pee\n  - match: sag\n    replace: sagg\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := speakOptions{modelID: "speech-02-hd", voiceID: "v", speed: 1, localLexicon: lex}
+	restore, read := captureStderr(t)
+	req, err := buildMiniMaxTTSRequest(&cobra.Command{}, opts, "sag speaks TCP/IP")
+	restore()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if req.PronunciationDict == nil || strings.Join(req.PronunciationDict.Tone, ",") != "sag/sagg" {
+		t.Fatalf("unexpected tones: %+v", req.PronunciationDict)
+	}
+	if stderr := read(); !strings.Contains(stderr, `"TCP/IP"`) {
+		t.Fatalf("expected a warning naming the skipped rule, got %q", stderr)
+	}
+}
+
+func TestLexiconFilesFallsBackToEnv(t *testing.T) {
+	t.Setenv("SAG_LEXICON_FILE", "a.yaml"+string(os.PathListSeparator)+"b.pls")
+	cmd := &cobra.Command{}
+	var files []string
+	cmd.Flags().StringArrayVar(&files, "lexicon-file", nil, "")
+	if got := lexiconFiles(cmd, files); strings.Join(got, ",") != "a.yaml,b.pls" {
+		t.Fatalf("env fallback = %q", got)
+	}
+	if err := cmd.Flags().Set("lexicon-file", "c.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if got := lexiconFiles(cmd, files); strings.Join(got, ",") != "c.yaml" {
+		t.Fatalf("explicit flag = %q", got)
+	}
+}
+
+func TestStreamInputSkipsEnvLexiconFiles(t *testing.T) {
+	speak, _, err := rootCmd.Find([]string{"speak"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flag := speak.Flags().Lookup("lexicon-file")
+	files := flag.Value.(pflag.SliceValue)
+	t.Cleanup(func() {
+		_ = files.Replace(nil)
+		flag.Changed = false
+		for name, value := range map[string]string{"stream-input": "false", "model-id": "eleven_v3", "voice": ""} {
+			f := speak.Flags().Lookup(name)
+			_ = f.Value.Set(value)
+			f.Changed = false
+		}
+	})
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	// A SAG_LEXICON_FILE default only warns.
+	t.Setenv("SAG_LEXICON_FILE", "team.yaml")
+	restore, read := captureStderr(t)
+	_, err = executeRoot(t, srv.URL, "speak", "--stream-input", "--model-id", "eleven_flash_v2_5", "-v", testVoiceID, "hi")
+	restore()
+	if err != nil && strings.Contains(err.Error(), "--lexicon-file") {
+		t.Fatalf("env lexicon should not block --stream-input: %v", err)
+	}
+	if !strings.Contains(read(), "not applied with --stream-input") {
+		t.Fatal("expected a warning about the skipped lexicon")
+	}
+
+	_, err = executeRoot(t, srv.URL, "speak", "--stream-input", "--model-id", "eleven_flash_v2_5", "-v", testVoiceID, "--lexicon-file", "team.yaml", "hi")
+	if err == nil || !strings.Contains(err.Error(), "--lexicon-file cannot be combined") {
+		t.Fatalf("expected explicit --lexicon-file to be rejected, got %v", err)
+	}
+}
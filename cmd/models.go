@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
+)
+
+const modelCacheFileName = "models.json"
+
+// modelInfo is what sag knows about a TTS model. Built-in entries follow the providers' docs;
+// `sag models` refreshes the ElevenLabs ones from /v1/models and caches them.
+type modelInfo struct {
+	ID   string `json:"model_id"`
+	Name string `json:"name,omitempty"`
+	// Languages lists ISO 639-1 codes; empty means sag does not restrict --lang.
+	Languages    []string `json:"languages,omitempty"`
+	MaxChars     int      `json:"max_characters,omitempty"`
+	SSML         bool     `json:"ssml"`
+	Style        bool     `json:"style"`
+	SpeakerBoost bool     `json:"speaker_boost"`
+	// Dialogue marks ElevenLabs models that render multi-speaker scripts via text-to-dialogue.
+	Dialogue        bool      `json:"dialogue,omitempty"`
+	StabilityValues []float64 `json:"stability_values,omitempty"`
+}
+
+type modelCache struct {
+	Version   int                    `json:"version"`
+	FetchedAt time.Time              `json:"fetched_at"`
+	Models    map[string][]modelInfo `json:"models"`
+}
+
+// ssmlTagPattern matches the SSML tags ElevenLabs honors in plain-text prompts.
+var ssmlTagPattern = regexp.MustCompile(`(?i)<\s*(break|phoneme)\b`)
+
+func init() {
+	var provider string
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "models",
+		Short: "List TTS models with their languages, request limits, and supported settings",
+		Long:  "Lists each provider's models. ElevenLabs models are fetched from the API when a key is configured and cached, so speak validates flags against the live catalog.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			names := providerNames()
+			if provider != "" {
+				spec, err := lookupProvider(provider)
+				if err != nil {
+					return err
+				}
+				names = []string{spec.name}
+			}
+			for _, name := range names {
+				if name == providerElevenLabs {
+					refreshElevenLabsModels(cmd.Context())
+				}
+			}
+
+			catalog := map[string][]modelInfo{}
+			for _, name := range names {
+				spec, err := lookupProvider(name)
+				if err != nil {
+					return err
+				}
+				catalog[name] = spec.catalog()
+			}
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), catalog)
+			}
+			return printModels(cmd.OutOrStdout(), names, catalog)
+		},
+	}
+	cmd.Flags().StringVar(&provider, "provider", "", "Only list this provider's models")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the catalog as JSON")
+	rootCmd.AddCommand(cmd)
+}
+
+// refreshElevenLabsModels updates the cached ElevenLabs catalog. Without a key or network, the built-in one is used.
+func refreshElevenLabsModels(ctx context.Context) {
+	if err := ensureAPIKey(); err != nil {
+		fmt.Fprintln(os.Stderr, "showing built-in ElevenLabs models; set ELEVENLABS_API_KEY to fetch the live catalog")
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	models, err := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL).ListModels(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "showing built-in ElevenLabs models: %v\n", err)
+		return
+	}
+	infos := make([]modelInfo, 0, len(models))
+	for _, m := range models {
+		if !m.CanDoTextToSpeech {
+			continue
+		}
+		info := modelInfo{ID: m.ModelID, Name: m.Name, MaxChars: m.MaxChars(), Style: m.CanUseStyle, SpeakerBoost: m.CanUseSpeakerBoost}
+		for _, l := range m.Languages {
+			info.Languages = append(info.Languages, strings.ToLower(l.LanguageID))
+		}
+		infos = append(infos, info)
+	}
+	saveModelCache(providerElevenLabs, infos)
+}
+
+// catalog returns the provider's models: built-in entries updated from the cache, then cached-only models.
+func (s providerSpec) catalog() []modelInfo {
+	fetched := loadCachedModels(s.name)
+	byID := make(map[string]modelInfo, len(fetched))
+	for _, m := range fetched {
+		byID[m.ID] = m
+	}
+	out := make([]modelInfo, 0, len(s.models)+len(fetched))
+	seen := map[string]bool{}
+	for _, m := range s.models {
+		if f, ok := byID[m.ID]; ok {
+			m = mergeModelInfo(m, f)
+		}
+		out = append(out, m)
+		seen[m.ID] = true
+	}
+	for _, f := range fetched {
+		if !seen[f.ID] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// model looks up modelID in the provider's catalog.
+func (s providerSpec) model(modelID string) (modelInfo, bool) {
+	for _, m := range s.catalog() {
+		if m.ID == modelID {
+			return m, true
+		}
+	}
+	return modelInfo{}, false
+}
+
+// mergeModelInfo overlays what the API reports onto a built-in entry. SSML support, dialogue support,
+// and stability steps are not reported by the API, so they stay as built in.
+func mergeModelInfo(builtin, fetched modelInfo) modelInfo {
+	out := fetched
+	out.SSML = builtin.SSML
+	out.Dialogue = builtin.Dialogue
+	out.StabilityValues = builtin.StabilityValues
+	if out.Name == "" {
+		out.Name = builtin.Name
+	}
+	if out.MaxChars == 0 {
+		out.MaxChars = builtin.MaxChars
+	}
+	return out
+}
+
+// validateElevenLabsModel rejects settings and text the model cannot handle, before any request is sent.
+// Unknown models (e.g. speech-to-speech IDs) are not checked.
+func validateElevenLabsModel(cmd *cobra.Command, opts speakOptions, text string) error {
+	spec, err := lookupProvider(providerElevenLabs)
+	if err != nil {
+		return err
+	}
+	m, ok := spec.model(opts.modelID)
+	if !ok {
+		return nil
+	}
+	flags := cmd.Flags()
+	if flags.Changed("stability") && len(m.StabilityValues) > 0 && !floatEqualsOneOf(opts.stability, m.StabilityValues) {
+		return fmt.Errorf("for %s, stability must be one of %s", m.ID, formatFloats(m.StabilityValues))
+	}
+	if flags.Changed("style") && !m.Style {
+		return fmt.Errorf("%s does not support --style; models that do: %s", m.ID, strings.Join(modelsWith(spec, func(m modelInfo) bool { return m.Style }), ", "))
+	}
+	if (flags.Changed("speaker-boost") || flags.Changed("no-speaker-boost")) && !m.SpeakerBoost {
+		return fmt.Errorf("%s does not support speaker boost; models that do: %s", m.ID, strings.Join(modelsWith(spec, func(m modelInfo) bool { return m.SpeakerBoost }), ", "))
+	}
+	if lang := strings.ToLower(strings.TrimSpace(opts.lang)); flags.Changed("lang") && len(m.Languages) > 0 && !containsString(m.Languages, lang) {
+		return fmt.Errorf("%s does not support language %q (supported: %s)", m.ID, lang, strings.Join(m.Languages, ", "))
+	}
+	if !m.SSML && ssmlTagPattern.MatchString(text) {
+		return fmt.Errorf("%s does not support SSML tags like <break>; use audio tags such as [pause] or a v2 model (see sag prompting)", m.ID)
+	}
+	return nil
+}
+
+func modelsWith(spec providerSpec, pred func(modelInfo) bool) []string {
+	var ids []string
+	for _, m := range spec.catalog() {
+		if pred(m) {
+			ids = append(ids, m.ID)
+		}
+	}
+	return ids
+}
+
+func printModels(w io.Writer, providers []string, catalog map[string][]modelInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "PROVIDER\tMODEL\tMAX CHARS\tLANGUAGES\tSSML\tSTYLE\tSPEAKER BOOST"); err != nil {
+		return err
+	}
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+	for _, name := range providers {
+		for _, m := range catalog[name] {
+			maxChars := "-"
+			if m.MaxChars > 0 {
+				maxChars = strconv.Itoa(m.MaxChars)
+			}
+			langs := "any"
+			switch n := len(m.Languages); {
+			case n > 3:
+				langs = fmt.Sprintf("%d languages", n)
+			case n > 0:
+				langs = strings.Join(m.Languages, ", ")
+			}
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", name, m.ID, maxChars, langs, yesNo(m.SSML), yesNo(m.Style), yesNo(m.SpeakerBoost)); err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func formatFloats(vals []float64) string {
+	parts := make([]string, len(vals))
+	for i, v := range vals {
+		parts[i] = strconv.FormatFloat(v, 'f', 1, 64)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func modelCachePath() (string, error) {
+	path, err := voiceCachePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), modelCacheFileName), nil
+}
+
+func loadModelCache() modelCache {
+	cache := modelCache{Version: 1, Models: map[string][]modelInfo{}}
+	path, err := modelCachePath()
+	if err != nil {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil || cache.Models == nil {
+		return modelCache{Version: 1, Models: map[string][]modelInfo{}}
+	}
+	return cache
+}
+
+func loadCachedModels(provider string) []modelInfo {
+	return loadModelCache().Models[provider]
+}
+
+func saveModelCache(provider string, models []modelInfo) {
+	path, err := modelCachePath()
+	if err != nil {
+		return
+	}
+	cache := loadModelCache()
+	cache.Models[provider] = models
+	cache.FetchedAt = time.Now().UTC()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestModelsCommandCachesLiveCatalog(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[
+			{"model_id":"eleven_flash_v2_5","can_do_text_to_speech":true,"can_use_style":false,"can_use_speaker_boost":true,"maximum_text_length_per_request":40000,"languages":[{"language_id":"en"},{"language_id":"de"}]},
+			{"model_id":"eleven_new_v4","can_do_text_to_speech":true,"can_use_style":true,"maximum_text_length_per_request":8000},
+			{"model_id":"eleven_english_sts_v2","can_do_text_to_speech":false}
+		]`))
+	}))
+	defer srv.Close()
+
+	out, err := executeRoot(t, srv.URL, "models", "--provider", "elevenlabs")
+	if err != nil {
+		t.Fatalf("models: %v", err)
+	}
+	if !strings.Contains(out, "eleven_new_v4") || strings.Contains(out, "sts") || !strings.Contains(out, "en, de") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+
+	spec, err := lookupProvider(providerElevenLabs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := spec.resolveModel("ELEVEN_NEW_V4"); err != nil || got != "eleven_new_v4" {
+		t.Fatalf("cached model should be accepted: %q, %v", got, err)
+	}
+	if got := spec.maxChars("eleven_new_v4"); got != 8000 {
+		t.Fatalf("maxChars = %d, want 8000", got)
+	}
+	if m, ok := spec.model("eleven_flash_v2_5"); !ok || !m.SSML || len(m.Languages) != 2 {
+		t.Fatalf("cached entry should keep built-in SSML support: %+v", m)
+	}
+}
+
+func TestValidateElevenLabsModel(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	newCmd := func(args ...string) (*cobra.Command, speakOptions) {
+		opts := speakOptions{}
+		cmd := &cobra.Command{}
+		cmd.Flags().StringVar(&opts.modelID, "model-id", "", "")
+		cmd.Flags().Float64Var(&opts.stability, "stability", 0, "")
+		cmd.Flags().Float64Var(&opts.style, "style", 0, "")
+		cmd.Flags().BoolVar(&opts.speakerBoost, "speaker-boost", false, "")
+		cmd.Flags().BoolVar(&opts.noSpeakerBoost, "no-speaker-boost", false, "")
+		cmd.Flags().StringVar(&opts.lang, "lang", "", "")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd, opts
+	}
+	cases := []struct {
+		args    []string
+		text    string
+		wantErr string
+	}{
+		{[]string{"--model-id", "eleven_multilingual_v2", "--style", "0.3"}, `Hi <break time="1s" /> there`, ""},
+		{[]string{"--model-id", "eleven_flash_v2_5", "--style", "0.3"}, "Hi", "does not support --style"},
+		{[]string{"--model-id", "eleven_turbo_v2", "--lang", "de"}, "Hallo", `does not support language "de"`},
+		{[]string{"--model-id", "eleven_v3"}, `Hi <break time="1s"/>`, "does not support SSML"},
+		{[]string{"--model-id", "eleven_v3", "--stability", "0.3"}, "Hi", "stability must be one of 0.0, 0.5, 1.0"},
+		{[]string{"--model-id", "eleven_english_sts_v2", "--style", "0.3"}, "Hi", ""},
+	}
+	for _, tc := range cases {
+		cmd, opts := newCmd(tc.args...)
+		err := validateElevenLabsModel(cmd, opts, tc.text)
+		if tc.wantErr == "" && err != nil {
+			t.Fatalf("%v: unexpected error %v", tc.args, err)
+		}
+		if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+			t.Fatalf("%v: expected %q, got %v", tc.args, tc.wantErr, err)
+		}
+	}
+}
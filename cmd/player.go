@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/steipete/sag/internal/audio"
+)
+
+var (
+	outputMu   sync.Mutex
+	outputSpec string
+	output     audio.Output
+)
+
+// playAudio plays audio through the backend picked by --player (or SAG_PLAYER).
+var playAudio = func(ctx context.Context, r io.Reader, format string) error {
+	out, err := audioOutput()
+	if err != nil {
+		return err
+	}
+	return out.Play(ctx, r, format)
+}
+
+// checkPlayer resolves the --player backend when audio will be played, so a bad value fails
+// before anything is generated; commands that never play ignore it.
+func checkPlayer(play bool) error {
+	if !play {
+		return nil
+	}
+	_, err := audioOutput()
+	return err
+}
+
+// audioOutput returns the playback backend, reusing it while the spec is unchanged so
+// wav-capture keeps appending to the same recording.
+func audioOutput() (audio.Output, error) {
+	spec := strings.TrimSpace(cfg.Player)
+	if spec == "" {
+		spec = strings.TrimSpace(os.Getenv("SAG_PLAYER"))
+	}
+
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	if output != nil && spec == outputSpec {
+		return output, nil
+	}
+	out, err := audio.NewOutput(spec)
+	if err != nil {
+		return nil, err
+	}
+	output, outputSpec = out, spec
+	return out, nil
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// usePlayer selects a backend through SAG_PLAYER. --player values stick to the root command
+// between executions, so they are cleared as well.
+func usePlayer(t *testing.T, spec string) {
+	t.Helper()
+	t.Cleanup(func() { cfg.Player = "" })
+	t.Setenv("SAG_PLAYER", spec)
+}
+
+func TestHistoryPlayRecordsWithWAVCapture(t *testing.T) {
+	samples := []byte{1, 0, 2, 0, 3, 0}
+	wav := &bytes.Buffer{}
+	wav.WriteString("RIFF")
+	_ = binary.Write(wav, binary.LittleEndian, uint32(36+len(samples)))
+	wav.WriteString("WAVEfmt ")
+	for _, v := range []any{uint32(16), uint16(1), uint16(1), uint32(22050), uint32(44100), uint16(2), uint16(16)} {
+		_ = binary.Write(wav, binary.LittleEndian, v)
+	}
+	wav.WriteString("data")
+	_ = binary.Write(wav, binary.LittleEndian, uint32(len(samples)))
+	wav.Write(samples)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(wav.Bytes())
+	}))
+	defer srv.Close()
+
+	capture := filepath.Join(t.TempDir(), "capture.wav")
+	usePlayer(t, "wav-capture:"+capture)
+	if _, err := executeRoot(t, srv.URL, "history", "play", "h1"); err != nil {
+		t.Fatalf("history play: %v", err)
+	}
+
+	data, err := os.ReadFile(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 44+12 || binary.LittleEndian.Uint32(data[24:28]) != 22050 || binary.LittleEndian.Uint16(data[22:24]) != 2 {
+		t.Fatalf("unexpected capture header % x", data[:min(len(data), 44)])
+	}
+	if want := []byte{1, 0, 1, 0, 2, 0, 2, 0, 3, 0, 3, 0}; !bytes.Equal(data[44:], want) {
+		t.Fatalf("captured % x, want % x", data[44:], want)
+	}
+}
+
+func TestSFXPlaysToNullPlayer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ID3door"))
+	}))
+	defer srv.Close()
+
+	usePlayer(t, "")
+	if _, err := executeRoot(t, srv.URL, "sfx", "--player", "null", "door"); err != nil {
+		t.Fatalf("sfx: %v", err)
+	}
+}
+
+func TestUnknownPlayerFailsBeforeRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("no request expected")
+	}))
+	defer srv.Close()
+
+	usePlayer(t, "sag-no-such-player")
+	_, err := executeRoot(t, srv.URL, "sfx", "door")
+	if err == nil || !strings.Contains(err.Error(), "sag-no-such-player") {
+		t.Fatalf("expected player lookup error, got %v", err)
+	}
+}
+
+func TestUnknownPlayerIgnoredWithoutPlayback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ID3door"))
+	}))
+	defer srv.Close()
+
+	usePlayer(t, "sag-no-such-player")
+	if _, err := executeRoot(t, srv.URL, "prompting"); err != nil {
+		t.Fatalf("prompting: %v", err)
+	}
+	sfx, _, _ := rootCmd.Find([]string{"sfx"})
+	t.Cleanup(func() {
+		f := sfx.Flags().Lookup("output")
+		_ = f.Value.Set("")
+		f.Changed = false
+	})
+	out := filepath.Join(t.TempDir(), "door.mp3")
+	if _, err := executeRoot(t, srv.URL, "sfx", "door", "-o", out); err != nil {
+		t.Fatalf("sfx -o without playback: %v", err)
+	}
+}
+
+func TestUnknownPlayerIgnoredForAsyncSpeak(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var created bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/t2a_async_v2" {
+			http.NotFound(w, r)
+			return
+		}
+		created = true
+		_, _ = w.Write([]byte(`{"task_id":42,"base_resp":{"status_code":0,"status_msg":"success"}}`))
+	}))
+	defer srv.Close()
+	t.Setenv("MINIMAX_BASE_URL", srv.URL)
+
+	usePlayer(t, "sag-no-such-player")
+	speak, _, _ := rootCmd.Find([]string{"speak"})
+	t.Cleanup(func() {
+		for name, value := range map[string]string{"provider": "", "model-id": "eleven_v3", "voice": "", "async": "false"} {
+			f := speak.Flags().Lookup(name)
+			_ = f.Value.Set(value)
+			f.Changed = false
+		}
+	})
+	if _, err := executeRoot(t, srv.URL, "speak", "--provider", "minimax", "--model-id", "speech-02-hd", "-v", "male-qn-qingse", "--async", "hi"); err != nil {
+		t.Fatalf("speak --async: %v", err)
+	}
+	if !created {
+		t.Fatal("expected an async task to be created")
+	}
+}
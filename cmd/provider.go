@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/steipete/sag/internal/subtitles"
+
+	"github.com/spf13/cobra"
+)
+
+// ttsProvider is a speech backend that the speak pipeline can drive.
+type ttsProvider interface {
+	Name() string
+	Capabilities() providerCapabilities
+	ListVoices(ctx context.Context) ([]providerVoice, error)
+	ResolveVoice(ctx context.Context, input string, forceID bool) (string, error)
+	FormatForPath(path string) string
+	BuildRequest(cmd *cobra.Command, opts speakOptions, text string) (ttsRequest, error)
+	Stream(ctx context.Context, req ttsRequest) (io.ReadCloser, error)
+	Convert(ctx context.Context, req ttsRequest) ([]byte, error)
+}
+
+// inputStreamer is implemented by providers that can synthesize text while it is still arriving.
+type inputStreamer interface {
+	StreamInput(ctx context.Context, req ttsRequest, text io.Reader, chunkSchedule []int) (io.ReadCloser, error)
+}
+
+// rawFormatNamer is implemented by providers whose raw PCM format name omits the sample rate.
+type rawFormatNamer interface {
+	// PCMFormat returns format with its implied rate spelled out (e.g. "pcm" -> "pcm_24000").
+	PCMFormat(format string) string
+}
+
+// timedStream is streamed audio whose timings are complete once it returns io.EOF.
+type timedStream interface {
+	io.ReadCloser
+	Timings() subtitles.Timings
+}
+
+// timingProvider is implemented by providers that can return character or word timings with the audio.
+type timingProvider interface {
+	Name() string
+	StreamTimed(ctx context.Context, req ttsRequest) (timedStream, error)
+	ConvertTimed(ctx context.Context, req ttsRequest) ([]byte, subtitles.Timings, error)
+}
+
+// chunkContext is the neighbouring text and earlier request IDs of one chunk of a long text.
+type chunkContext struct {
+	previousText       string
+	nextText           string
+	previousRequestIDs []string
+}
+
+// stitchingProvider is implemented by providers that keep prosody continuous across chunked requests.
+// It returns the request ID to pass to later chunks.
+type stitchingProvider interface {
+	StreamStitched(ctx context.Context, req ttsRequest, cc chunkContext) (io.ReadCloser, string, error)
+}
+
+// asyncProvider is implemented by providers that run long texts as server-side jobs.
+// The returned record is stored locally so the job can be resumed with `sag jobs`.
+type asyncProvider interface {
+	SubmitAsync(ctx context.Context, req ttsRequest) (jobRecord, error)
+}
+
+// providerCapabilities describes what a provider supports.
+type providerCapabilities struct {
+	Streaming    bool
+	LatencyTiers bool
+}
+
+// providerVoice is the provider-neutral view of a voice.
+type providerVoice struct {
+	ID          string
+	Name        string
+	Category    string
+	Description string
+}
+
+// ttsRequest is a synthesis request prepared by a provider from speak flags.
+// The payload is provider specific and only interpreted by the provider that built it.
+type ttsRequest struct {
+	voiceID     string
+	latencyTier int
+	payload     any
+}
+
+// providerSpec registers a provider with the speak pipeline.
+type providerSpec struct {
+	name         string
+	voiceEnv     string
+	defaultModel string
+	// models is the built-in model catalog; see catalog for the cached live one.
+	models []modelInfo
+	// passthroughModels accepts model IDs outside the catalog (self-hosted servers name their own).
+	passthroughModels bool
+	// charLimit is the per-request text limit for models without their own MaxChars. 0 means unlimited.
+	charLimit    int
+	ensureAPIKey func() error
+	newProvider  func() ttsProvider
+}
+
+var providerRegistry = map[string]providerSpec{}
+
+func registerProvider(spec providerSpec) {
+	providerRegistry[spec.name] = spec
+}
+
+func lookupProvider(name string) (providerSpec, error) {
+	spec, ok := providerRegistry[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return providerSpec{}, fmt.Errorf("unknown provider %q (available: %s)", name, strings.Join(providerNames(), ", "))
+	}
+	return spec, nil
+}
+
+func providerNames() []string {
+	names := make([]string, 0, len(providerRegistry))
+	for name := range providerRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveModel validates modelID against the provider's catalog and returns its canonical spelling.
+// An empty modelID selects the provider default.
+func (s providerSpec) resolveModel(modelID string) (string, error) {
+	modelID = strings.TrimSpace(modelID)
+	if modelID == "" {
+		return s.defaultModel, nil
+	}
+	catalog := s.catalog()
+	ids := make([]string, 0, len(catalog))
+	for _, m := range catalog {
+		if strings.EqualFold(m.ID, modelID) {
+			return m.ID, nil
+		}
+		ids = append(ids, m.ID)
+	}
+	if s.passthroughModels {
+		return modelID, nil
+	}
+	return "", fmt.Errorf("model %q is not available for %s; valid models: %s", modelID, s.name, strings.Join(ids, ", "))
+}
+
+// maxChars returns how many characters a single request to modelID may carry (0 = unlimited).
+func (s providerSpec) maxChars(modelID string) int {
+	if m, ok := s.model(modelID); ok && m.MaxChars > 0 {
+		return m.MaxChars
+	}
+	return s.charLimit
+}
+
+// selectProvider picks the provider from an explicit name, SAG_PROVIDER, or the model ID prefix (in that order).
+func selectProvider(explicit, modelID string) (providerSpec, error) {
+	name := strings.TrimSpace(explicit)
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv("SAG_PROVIDER"))
+	}
+	if name == "" {
+		name = detectProvider(modelID)
+	}
+	return lookupProvider(name)
+}
+
+// voiceFromEnv returns the default voice configured for the provider, if any.
+func voiceFromEnv(spec providerSpec) string {
+	for _, key := range []string{spec.voiceEnv, "SAG_VOICE_ID"} {
+		if key == "" {
+			continue
+		}
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func printProviderVoices(ctx context.Context, p ttsProvider) error {
+	voices, err := p.ListVoices(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintf(w, "VOICE ID\tNAME\tCATEGORY\tDESCRIPTION\n"); err != nil {
+		return err
+	}
+	for _, v := range voices {
+		desc := strings.ReplaceAll(v.Description, "\t", " ")
+		desc = strings.ReplaceAll(desc, "\n", " ")
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.ID, v.Name, v.Category, desc); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+	"github.com/steipete/sag/internal/subtitles"
+
+	"github.com/spf13/cobra"
+)
+
+type elevenLabsProvider struct {
+	client *elevenlabs.Client
+	// locators caches the resolved --lexicon values so chunked requests look them up once.
+	locators []elevenlabs.PronunciationDictionaryLocator
+}
+
+func init() {
+	registerProvider(providerSpec{
+		name:         providerElevenLabs,
+		voiceEnv:     "ELEVENLABS_VOICE_ID",
+		defaultModel: "eleven_v3",
+		models: []modelInfo{
+			{ID: "eleven_v3", Name: "Eleven v3", MaxChars: 5000, Style: true, SpeakerBoost: true, Dialogue: true, StabilityValues: []float64{0, 0.5, 1}},
+			{ID: "eleven_multilingual_v2", Name: "Eleven Multilingual v2", MaxChars: 10000, SSML: true, Style: true, SpeakerBoost: true},
+			{ID: "eleven_flash_v2_5", Name: "Eleven Flash v2.5", MaxChars: 40000, SSML: true, SpeakerBoost: true},
+			{ID: "eleven_turbo_v2_5", Name: "Eleven Turbo v2.5", MaxChars: 40000, SSML: true, SpeakerBoost: true},
+			{ID: "eleven_flash_v2", Name: "Eleven Flash v2", Languages: []string{"en"}, MaxChars: 30000, SSML: true, SpeakerBoost: true},
+			{ID: "eleven_turbo_v2", Name: "Eleven Turbo v2", Languages: []string{"en"}, MaxChars: 30000, SSML: true, SpeakerBoost: true},
+			{ID: "eleven_multilingual_v1", Name: "Eleven Multilingual v1", MaxChars: 10000, SSML: true, SpeakerBoost: true},
+			{ID: "eleven_monolingual_v1", Name: "Eleven English v1", Languages: []string{"en"}, MaxChars: 10000, SSML: true, SpeakerBoost: true},
+		},
+		charLimit:    5000,
+		ensureAPIKey: ensureAPIKey,
+		newProvider: func() ttsProvider {
+			return newElevenLabsProvider(elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL))
+		},
+	})
+}
+
+func newElevenLabsProvider(client *elevenlabs.Client) *elevenLabsProvider {
+	return &elevenLabsProvider{client: client}
+}
+
+func (p *elevenLabsProvider) Name() string { return providerElevenLabs }
+
+func (p *elevenLabsProvider) Capabilities() providerCapabilities {
+	return providerCapabilities{Streaming: true, LatencyTiers: true}
+}
+
+func (p *elevenLabsProvider) ListVoices(ctx context.Context) ([]providerVoice, error) {
+	voices, err := p.client.ListVoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]providerVoice, 0, len(voices))
+	for _, v := range voices {
+		out = append(out, providerVoice{ID: v.VoiceID, Name: v.Name, Category: v.Category, Description: v.Description})
+	}
+	return out, nil
+}
+
+func (p *elevenLabsProvider) ResolveVoice(ctx context.Context, input string, forceID bool) (string, error) {
+	return resolveVoice(ctx, p.client, input, forceID)
+}
+
+func (p *elevenLabsProvider) FormatForPath(path string) string {
+	return inferFormatFromExt(path)
+}
+
+func (p *elevenLabsProvider) BuildRequest(cmd *cobra.Command, opts speakOptions, text string) (ttsRequest, error) {
+	payload, err := buildTTSRequest(cmd, opts, text)
+	if err != nil {
+		return ttsRequest{}, err
+	}
+	if len(opts.lexicons) > 0 {
+		if p.locators == nil {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			if p.locators, err = resolveLexiconLocators(ctx, p.client, opts.lexicons); err != nil {
+				return ttsRequest{}, err
+			}
+		}
+		payload.PronunciationDictionaryLocators = p.locators
+	}
+	return ttsRequest{voiceID: opts.voiceID, latencyTier: opts.latencyTier, payload: payload}, nil
+}
+
+func (p *elevenLabsProvider) Stream(ctx context.Context, req ttsRequest) (io.ReadCloser, error) {
+	payload, err := elevenLabsPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.StreamTTS(ctx, req.voiceID, payload, req.latencyTier)
+}
+
+// StreamStitched streams one chunk of a long text with its neighbours as context.
+// eleven_v3 does not support request stitching, so its chunks are generated independently.
+func (p *elevenLabsProvider) StreamStitched(ctx context.Context, req ttsRequest, cc chunkContext) (io.ReadCloser, string, error) {
+	payload, err := elevenLabsPayload(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if payload.ModelID != "eleven_v3" {
+		payload.PreviousText = cc.previousText
+		payload.NextText = cc.nextText
+		payload.PreviousRequestIDs = cc.previousRequestIDs
+	}
+	return p.client.StreamTTSWithRequestID(ctx, req.voiceID, payload, req.latencyTier)
+}
+
+func (p *elevenLabsProvider) StreamInput(ctx context.Context, req ttsRequest, text io.Reader, chunkSchedule []int) (io.ReadCloser, error) {
+	payload, err := elevenLabsPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	if payload.ModelID == "eleven_v3" {
+		return nil, errors.New("--stream-input is not available for eleven_v3; use --model-id eleven_flash_v2_5, eleven_turbo_v2_5 or eleven_multilingual_v2")
+	}
+	return p.client.StreamTTSInput(ctx, req.voiceID, text, elevenlabs.StreamInputOptions{
+		ModelID:                         payload.ModelID,
+		OutputFormat:                    payload.OutputFormat,
+		LanguageCode:                    payload.LanguageCode,
+		Latency:                         req.latencyTier,
+		VoiceSettings:                   payload.VoiceSettings,
+		ChunkLengthSchedule:             chunkSchedule,
+		PronunciationDictionaryLocators: payload.PronunciationDictionaryLocators,
+	})
+}
+
+func (p *elevenLabsProvider) Convert(ctx context.Context, req ttsRequest) ([]byte, error) {
+	payload, err := elevenLabsPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.ConvertTTS(ctx, req.voiceID, payload)
+}
+
+func (p *elevenLabsProvider) StreamTimed(ctx context.Context, req ttsRequest) (timedStream, error) {
+	payload, err := elevenLabsPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := p.client.StreamTTSWithTimestamps(ctx, req.voiceID, payload, req.latencyTier)
+	if err != nil {
+		return nil, err
+	}
+	return elevenLabsTimedStream{stream}, nil
+}
+
+func (p *elevenLabsProvider) ConvertTimed(ctx context.Context, req ttsRequest) ([]byte, subtitles.Timings, error) {
+	payload, err := elevenLabsPayload(req)
+	if err != nil {
+		return nil, subtitles.Timings{}, err
+	}
+	res, err := p.client.ConvertTTSWithTimestamps(ctx, req.voiceID, payload)
+	if err != nil {
+		return nil, subtitles.Timings{}, err
+	}
+	return res.Audio, subtitles.Timings{Chars: alignmentChars(res.Alignment)}, nil
+}
+
+type elevenLabsTimedStream struct {
+	*elevenlabs.TimestampStream
+}
+
+func (s elevenLabsTimedStream) Timings() subtitles.Timings {
+	return subtitles.Timings{Chars: alignmentChars(s.Alignment())}
+}
+
+func alignmentChars(a elevenlabs.Alignment) []subtitles.Char {
+	n := min(len(a.Characters), len(a.CharacterStartTimesSeconds), len(a.CharacterEndTimesSeconds))
+	out := make([]subtitles.Char, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, subtitles.Char{Text: a.Characters[i], Start: a.CharacterStartTimesSeconds[i], End: a.CharacterEndTimesSeconds[i]})
+	}
+	return out
+}
+
+func elevenLabsPayload(req ttsRequest) (elevenlabs.TTSRequest, error) {
+	payload, ok := req.payload.(elevenlabs.TTSRequest)
+	if !ok {
+		return elevenlabs.TTSRequest{}, fmt.Errorf("elevenlabs: unexpected request payload %T", req.payload)
+	}
+	return payload, nil
+}
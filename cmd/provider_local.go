@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/local"
+
+	"github.com/spf13/cobra"
+)
+
+type localProvider struct {
+	client *local.Client
+}
+
+func init() {
+	registerProvider(providerSpec{
+		name:         providerLocal,
+		voiceEnv:     "SAG_LOCAL_VOICE",
+		defaultModel: "local",
+		models:       []modelInfo{{ID: "local"}},
+		ensureAPIKey: func() error { return nil },
+		newProvider: func() ttsProvider {
+			return newLocalProvider(local.NewClient(os.Getenv("SAG_LOCAL_ENGINE"), localModelsDir()))
+		},
+	})
+}
+
+func newLocalProvider(client *local.Client) *localProvider {
+	return &localProvider{client: client}
+}
+
+func (p *localProvider) Name() string { return providerLocal }
+
+func (p *localProvider) Capabilities() providerCapabilities {
+	return providerCapabilities{Streaming: true}
+}
+
+func (p *localProvider) ListVoices(ctx context.Context) ([]providerVoice, error) {
+	voices, err := p.client.ListVoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]providerVoice, 0, len(voices))
+	for _, v := range voices {
+		out = append(out, providerVoice{ID: v.VoiceID, Name: v.Name, Category: "local", Description: v.Path})
+	}
+	return out, nil
+}
+
+func (p *localProvider) ResolveVoice(ctx context.Context, input string, forceID bool) (string, error) {
+	return resolveLocalVoice(ctx, p.client, input, forceID)
+}
+
+func (p *localProvider) FormatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav", ".wave":
+		return "wav"
+	default:
+		return ""
+	}
+}
+
+func (p *localProvider) BuildRequest(cmd *cobra.Command, opts speakOptions, text string) (ttsRequest, error) {
+	if opts.outputPath != "" && p.FormatForPath(opts.outputPath) == "" {
+		return ttsRequest{}, errors.New("local provider writes WAV; use a .wav output path")
+	}
+	// pcm_<rate> means the engine writes raw s16le mono (e.g. piper --output-raw); -o .wav adds the header.
+	if cmd.Flags().Changed("format") && !strings.EqualFold(strings.TrimSpace(opts.outputFmt), "wav") {
+		if _, ok := pcmRate(opts.outputFmt); !ok {
+			return ttsRequest{}, fmt.Errorf("format %q not supported for the local provider (use wav or pcm_<rate>)", opts.outputFmt)
+		}
+	}
+	return ttsRequest{voiceID: opts.voiceID, payload: local.TTSRequest{Text: text, Speed: opts.speed}}, nil
+}
+
+func (p *localProvider) Stream(ctx context.Context, req ttsRequest) (io.ReadCloser, error) {
+	payload, err := localPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.StreamTTS(ctx, req.voiceID, payload)
+}
+
+func (p *localProvider) Convert(ctx context.Context, req ttsRequest) ([]byte, error) {
+	payload, err := localPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.ConvertTTS(ctx, req.voiceID, payload)
+}
+
+func localPayload(req ttsRequest) (local.TTSRequest, error) {
+	payload, ok := req.payload.(local.TTSRequest)
+	if !ok {
+		return local.TTSRequest{}, fmt.Errorf("local: unexpected request payload %T", req.payload)
+	}
+	return payload, nil
+}
+
+func resolveLocalVoice(ctx context.Context, client *local.Client, voiceInput string, forceID bool) (string, error) {
+	voiceInput = strings.TrimSpace(voiceInput)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if voiceInput == "" {
+		voices, err := client.ListVoices(ctx)
+		if err != nil {
+			return "", fmt.Errorf("voice not specified and failed to list local voices: %w", err)
+		}
+		if len(voices) == 0 {
+			return "", fmt.Errorf("no voices found in %s; add models there, set SAG_LOCAL_MODELS, or specify --voice", localModelsDir())
+		}
+		fmt.Fprintf(os.Stderr, "defaulting to voice %s (%s)\n", voices[0].Name, voices[0].VoiceID)
+		return voices[0].VoiceID, nil
+	}
+	if forceID {
+		return voiceInput, nil
+	}
+
+	voices, err := client.ListVoices(ctx)
+	if err != nil {
+		// Engines such as espeak-ng take voice names directly; pass them through.
+		return voiceInput, nil
+	}
+	voiceInputLower := strings.ToLower(voiceInput)
+	for _, v := range voices {
+		if strings.ToLower(v.VoiceID) == voiceInputLower || strings.ToLower(v.Name) == voiceInputLower {
+			return v.VoiceID, nil
+		}
+	}
+	for _, v := range voices {
+		if strings.Contains(strings.ToLower(v.VoiceID), voiceInputLower) {
+			fmt.Fprintf(os.Stderr, "using voice %s (%s)\n", v.Name, v.VoiceID)
+			return v.VoiceID, nil
+		}
+	}
+	return voiceInput, nil
+}
+
+// localModelsDir returns SAG_LOCAL_MODELS or the per-user data directory for local voices.
+func localModelsDir() string {
+	if dir := strings.TrimSpace(os.Getenv("SAG_LOCAL_MODELS")); dir != "" {
+		return dir
+	}
+	if dir := strings.TrimSpace(os.Getenv("XDG_DATA_HOME")); dir != "" {
+		return filepath.Join(dir, "sag", "voices")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share", "sag", "voices")
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/local"
+
+	"github.com/spf13/cobra"
+)
+
+func newStubLocalProvider(t *testing.T) (*localProvider, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub engine requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"en_US-amy-low.onnx", "en_US-amy-low.onnx.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("write model: %v", err)
+		}
+	}
+	engine := filepath.Join(t.TempDir(), "engine.sh")
+	script := "#!/bin/sh\nprintf 'RIFF[%s]' \"$(basename \"$1\")\"\ncat\n"
+	if err := os.WriteFile(engine, []byte(script), 0o755); err != nil {
+		t.Fatalf("write engine: %v", err)
+	}
+	return newLocalProvider(local.NewClient(engine+" {model}", dir)), dir
+}
+
+func TestLocalProviderStreamWritesOutput(t *testing.T) {
+	provider, _ := newStubLocalProvider(t)
+	voiceID, err := provider.ResolveVoice(context.Background(), "amy", false)
+	if err != nil {
+		t.Fatalf("ResolveVoice error: %v", err)
+	}
+	if voiceID != "en_US-amy-low" {
+		t.Fatalf("ResolveVoice = %q, want en_US-amy-low", voiceID)
+	}
+
+	out := filepath.Join(t.TempDir(), "out.wav")
+	opts := speakOptions{voiceID: voiceID, outputPath: out, stream: true, speed: 1}
+	req, err := provider.BuildRequest(newSpeakFormatCommand(t), opts, "hello local")
+	if err != nil {
+		t.Fatalf("BuildRequest error: %v", err)
+	}
+	if _, err := streamAndPlay(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("streamAndPlay error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "RIFF[en_US-amy-low.onnx]hello local" {
+		t.Fatalf("unexpected output: %q", string(data))
+	}
+}
+
+func TestLocalProviderRejectsNonWAVOutput(t *testing.T) {
+	provider, _ := newStubLocalProvider(t)
+	opts := speakOptions{voiceID: "v", outputPath: "out.mp3"}
+	_, err := provider.BuildRequest(newSpeakFormatCommand(t), opts, "hi")
+	if err == nil || !strings.Contains(err.Error(), "writes WAV") {
+		t.Fatalf("expected WAV output error, got %v", err)
+	}
+}
+
+func TestLocalProviderListsVoices(t *testing.T) {
+	provider, _ := newStubLocalProvider(t)
+	restore, read := captureStdout(t)
+	defer restore()
+
+	if err := printProviderVoices(context.Background(), provider); err != nil {
+		t.Fatalf("printProviderVoices error: %v", err)
+	}
+	if out := read(); !strings.Contains(out, "en_US-amy-low") || strings.Contains(out, ".json") {
+		t.Fatalf("unexpected voice table: %q", out)
+	}
+}
+
+func TestLocalModelsDirPrefersEnv(t *testing.T) {
+	t.Setenv("SAG_LOCAL_MODELS", "/models")
+	if got := localModelsDir(); got != "/models" {
+		t.Fatalf("localModelsDir = %q, want /models", got)
+	}
+	t.Setenv("SAG_LOCAL_MODELS", "")
+	t.Setenv("XDG_DATA_HOME", "/data")
+	if got := localModelsDir(); got != filepath.Join("/data", "sag", "voices") {
+		t.Fatalf("localModelsDir = %q", got)
+	}
+}
+
+func newSpeakFormatCommand(t *testing.T) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "speak"}
+	cmd.Flags().String("format", "", "")
+	return cmd
+}
+
+func TestLocalProviderWrapsRawPCMInWAV(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub engine requires a POSIX shell")
+	}
+	engine := filepath.Join(t.TempDir(), "engine.sh")
+	if err := os.WriteFile(engine, []byte("#!/bin/sh\ncat\n"), 0o755); err != nil {
+		t.Fatalf("write engine: %v", err)
+	}
+	provider := newLocalProvider(local.NewClient(engine, t.TempDir()))
+
+	cmd := newSpeakFormatCommand(t)
+	if err := cmd.ParseFlags([]string{"--format", "pcm_22050"}); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "out.wav")
+	opts := speakOptions{voiceID: "v", outputPath: out, outputFmt: "pcm_22050", stream: true, speed: 1}
+	applyOutputPath(cmd, provider, &opts)
+	if opts.outputFmt != "pcm_22050" {
+		t.Fatalf("outputFmt = %q, want pcm_22050", opts.outputFmt)
+	}
+	req, err := provider.BuildRequest(cmd, opts, "hi")
+	if err != nil {
+		t.Fatalf("BuildRequest error: %v", err)
+	}
+	if _, err := streamAndPlay(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("streamAndPlay error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if len(data) != 46 || string(data[:4]) != "RIFF" || binary.LittleEndian.Uint32(data[24:28]) != 22050 || string(data[44:]) != "hi" {
+		t.Fatalf("unexpected output %q", data)
+	}
+
+	opts.outputFmt = "mp3_44100_128"
+	if err := cmd.ParseFlags([]string{"--format", "mp3_44100_128"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.BuildRequest(cmd, opts, "hi"); err == nil || !strings.Contains(err.Error(), "pcm_<rate>") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+}
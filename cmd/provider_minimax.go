@@ -0,0 +1,443 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/minimax"
+	"github.com/steipete/sag/internal/subtitles"
+
+	"github.com/spf13/cobra"
+)
+
+type miniMaxProvider struct {
+	client *minimax.Client
+}
+
+func init() {
+	registerProvider(providerSpec{
+		name:         providerMiniMax,
+		voiceEnv:     "MINIMAX_VOICE_ID",
+		defaultModel: "speech-02-hd",
+		models: []modelInfo{
+			{ID: "speech-2.6-hd", Name: "Speech 2.6 HD"},
+			{ID: "speech-2.6-turbo", Name: "Speech 2.6 Turbo"},
+			{ID: "speech-02-hd", Name: "Speech 02 HD"},
+			{ID: "speech-02-turbo", Name: "Speech 02 Turbo"},
+			{ID: "speech-01-hd", Name: "Speech 01 HD"},
+			{ID: "speech-01-turbo", Name: "Speech 01 Turbo"},
+		},
+		charLimit:    9999, // sync t2a_v2 requires fewer than 10,000 characters
+		ensureAPIKey: ensureMiniMaxAPIKey,
+		newProvider: func() ttsProvider {
+			return newMiniMaxProvider(minimax.NewClient(cfg.APIKey, minimaxBaseURL()))
+		},
+	})
+}
+
+func newMiniMaxProvider(client *minimax.Client) *miniMaxProvider {
+	return &miniMaxProvider{client: client}
+}
+
+func (p *miniMaxProvider) Name() string { return providerMiniMax }
+
+func (p *miniMaxProvider) Capabilities() providerCapabilities {
+	return providerCapabilities{Streaming: true}
+}
+
+func (p *miniMaxProvider) ListVoices(ctx context.Context) ([]providerVoice, error) {
+	voices, err := p.client.ListVoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]providerVoice, 0, len(voices))
+	for _, v := range voices {
+		out = append(out, providerVoice{ID: v.VoiceID, Name: v.Name, Category: v.Category, Description: v.Description})
+	}
+	return out, nil
+}
+
+func (p *miniMaxProvider) ResolveVoice(ctx context.Context, input string, forceID bool) (string, error) {
+	return resolveMiniMaxVoice(ctx, p.client, input, forceID)
+}
+
+func (p *miniMaxProvider) FormatForPath(path string) string {
+	return inferMiniMaxFormatFromExt(path)
+}
+
+func (p *miniMaxProvider) BuildRequest(cmd *cobra.Command, opts speakOptions, text string) (ttsRequest, error) {
+	payload, err := buildMiniMaxTTSRequest(cmd, opts, text)
+	if err != nil {
+		return ttsRequest{}, err
+	}
+	return ttsRequest{voiceID: opts.voiceID, payload: payload}, nil
+}
+
+func (p *miniMaxProvider) Stream(ctx context.Context, req ttsRequest) (io.ReadCloser, error) {
+	payload, err := miniMaxPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.StreamTTS(ctx, req.voiceID, payload)
+}
+
+func (p *miniMaxProvider) Convert(ctx context.Context, req ttsRequest) ([]byte, error) {
+	payload, err := miniMaxPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.ConvertTTS(ctx, req.voiceID, payload)
+}
+
+func (p *miniMaxProvider) SubmitAsync(ctx context.Context, req ttsRequest) (jobRecord, error) {
+	payload, err := miniMaxPayload(req)
+	if err != nil {
+		return jobRecord{}, err
+	}
+	task, err := p.client.CreateAsyncTTS(ctx, req.voiceID, payload)
+	if err != nil {
+		return jobRecord{}, err
+	}
+	return jobRecord{
+		TaskID:   task.TaskID,
+		Provider: providerMiniMax,
+		Model:    payload.Model,
+		VoiceID:  req.voiceID,
+		Status:   task.Status,
+		FileID:   task.FileID,
+	}, nil
+}
+
+// StreamTimed falls back to a non-streaming request: MiniMax only returns subtitles for those.
+func (p *miniMaxProvider) StreamTimed(ctx context.Context, req ttsRequest) (timedStream, error) {
+	data, timings, err := p.ConvertTimed(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return bufferedTimedStream{ReadCloser: io.NopCloser(bytes.NewReader(data)), timings: timings}, nil
+}
+
+func (p *miniMaxProvider) ConvertTimed(ctx context.Context, req ttsRequest) ([]byte, subtitles.Timings, error) {
+	payload, err := miniMaxPayload(req)
+	if err != nil {
+		return nil, subtitles.Timings{}, err
+	}
+	data, segments, err := p.client.ConvertTTSWithSubtitles(ctx, req.voiceID, payload)
+	if err != nil {
+		return nil, subtitles.Timings{}, err
+	}
+	return data, subtitles.Timings{Words: miniMaxSubtitleWords(segments)}, nil
+}
+
+type bufferedTimedStream struct {
+	io.ReadCloser
+	timings subtitles.Timings
+}
+
+func (s bufferedTimedStream) Timings() subtitles.Timings { return s.timings }
+
+// miniMaxSubtitleWords converts MiniMax subtitle segments (milliseconds) to words in seconds.
+// Segments without word timings are spread across their words by character count.
+func miniMaxSubtitleWords(segments []minimax.SubtitleSegment) []subtitles.Word {
+	var words []subtitles.Word
+	for _, seg := range segments {
+		if len(seg.Words) > 0 {
+			for _, w := range seg.Words {
+				words = append(words, subtitles.Word{Text: strings.TrimSpace(w.Word), Start: w.TimeBegin / 1000, End: w.TimeEnd / 1000})
+			}
+			continue
+		}
+		fields := strings.Fields(seg.Text)
+		total := 0
+		for _, f := range fields {
+			total += len([]rune(f))
+		}
+		if total == 0 {
+			continue
+		}
+		span := seg.TimeEnd - seg.TimeBegin
+		at, seen := seg.TimeBegin, 0
+		for _, f := range fields {
+			seen += len([]rune(f))
+			end := seg.TimeBegin + span*float64(seen)/float64(total)
+			words = append(words, subtitles.Word{Text: f, Start: at / 1000, End: end / 1000})
+			at = end
+		}
+	}
+	return words
+}
+
+func miniMaxPayload(req ttsRequest) (minimax.TTSRequest, error) {
+	payload, ok := req.payload.(minimax.TTSRequest)
+	if !ok {
+		return minimax.TTSRequest{}, fmt.Errorf("minimax: unexpected request payload %T", req.payload)
+	}
+	return payload, nil
+}
+
+func resolveMiniMaxVoice(ctx context.Context, client *minimax.Client, voiceInput string, forceID bool) (string, error) {
+	voiceInput = strings.TrimSpace(voiceInput)
+	if voiceInput == "" {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		voices, err := client.ListVoices(ctx)
+		if err != nil {
+			return "", fmt.Errorf("voice not specified and failed to fetch voices: %w", err)
+		}
+		if len(voices) == 0 {
+			return "", errors.New("no voices available; specify --voice or set MINIMAX_VOICE_ID")
+		}
+		fmt.Fprintf(os.Stderr, "defaulting to voice %s (%s)\n", voices[0].Name, voices[0].VoiceID)
+		return voices[0].VoiceID, nil
+	}
+	if forceID {
+		return voiceInput, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	voices, err := client.ListVoices(ctx)
+	if err != nil {
+		return voiceInput, nil
+	}
+	voiceInputLower := strings.ToLower(voiceInput)
+	for _, v := range voices {
+		if strings.ToLower(v.VoiceID) == voiceInputLower || strings.ToLower(v.Name) == voiceInputLower {
+			fmt.Fprintf(os.Stderr, "using voice %s (%s)\n", v.Name, v.VoiceID)
+			return v.VoiceID, nil
+		}
+	}
+	for _, v := range voices {
+		if strings.Contains(strings.ToLower(v.Name), voiceInputLower) {
+			fmt.Fprintf(os.Stderr, "using voice %s (%s)\n", v.Name, v.VoiceID)
+			return v.VoiceID, nil
+		}
+	}
+	return voiceInput, nil
+}
+
+func inferMiniMaxFormatFromExt(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".mp3":
+		return "mp3"
+	case ".wav", ".wave":
+		return "wav"
+	case ".flac":
+		return "flac"
+	default:
+		return ""
+	}
+}
+
+func minimaxBaseURL() string {
+	host := strings.TrimSpace(os.Getenv("MINIMAX_API_HOST"))
+	if host == "" {
+		host = strings.TrimSpace(os.Getenv("MINIMAX_BASE_URL"))
+	}
+	if host == "" {
+		return ""
+	}
+	if strings.HasPrefix(host, "http://") || strings.HasPrefix(host, "https://") {
+		return host
+	}
+	return "https://" + host
+}
+
+func buildMiniMaxTTSRequest(cmd *cobra.Command, opts speakOptions, text string) (minimax.TTSRequest, error) {
+	flags := cmd.Flags()
+
+	format, err := normalizeMiniMaxFormat(opts.outputFmt)
+	if err != nil {
+		return minimax.TTSRequest{}, err
+	}
+	formatExplicit := flags.Changed("format") || opts.outputPath != ""
+	if formatExplicit {
+		if opts.stream && format != "mp3" {
+			return minimax.TTSRequest{}, errors.New("MiniMax streaming supports mp3 only; use --no-stream for wav/flac")
+		}
+		if opts.play && format != "mp3" {
+			return minimax.TTSRequest{}, errors.New("MiniMax playback supports mp3 only; use --output without --play for wav/flac")
+		}
+	} else {
+		format = ""
+	}
+
+	var speedPtr *float64
+	if flags.Changed("speed") || flags.Changed("rate") {
+		speed := opts.speed
+		speedPtr = &speed
+	}
+
+	var volumePtr *float64
+	if flags.Changed("volume") {
+		if opts.minimaxVolume <= 0 || opts.minimaxVolume > 10 {
+			return minimax.TTSRequest{}, errors.New("volume must be between 0 and 10 (exclusive 0)")
+		}
+		volume := opts.minimaxVolume
+		volumePtr = &volume
+	}
+
+	var pitchPtr *int
+	if flags.Changed("pitch") {
+		if opts.minimaxPitch < -12 || opts.minimaxPitch > 12 {
+			return minimax.TTSRequest{}, errors.New("pitch must be between -12 and 12")
+		}
+		pitch := opts.minimaxPitch
+		pitchPtr = &pitch
+	}
+
+	emotion := strings.TrimSpace(opts.minimaxEmotion)
+	if flags.Changed("emotion") && emotion == "" {
+		return minimax.TTSRequest{}, errors.New("emotion cannot be empty")
+	}
+
+	var textNormPtr *bool
+	if flags.Changed("text-normalization") {
+		v := opts.minimaxTextNormalization
+		textNormPtr = &v
+	}
+
+	var latexReadPtr *bool
+	if flags.Changed("latex-read") {
+		v := opts.minimaxLatexRead
+		latexReadPtr = &v
+	}
+
+	var continuousSoundPtr *bool
+	if flags.Changed("continuous-sound") {
+		v := opts.minimaxContinuousSound
+		continuousSoundPtr = &v
+	}
+
+	var languageBoost string
+	if flags.Changed("language") || flags.Changed("accent") {
+		lang := strings.TrimSpace(opts.minimaxLanguage)
+		accent := strings.TrimSpace(opts.minimaxAccent)
+		if lang != "" && accent != "" && lang != accent {
+			return minimax.TTSRequest{}, errors.New("choose only one of --language or --accent (or set the same value)")
+		}
+		if lang != "" {
+			languageBoost = lang
+		} else {
+			languageBoost = accent
+		}
+		if languageBoost == "" {
+			return minimax.TTSRequest{}, errors.New("language/accent cannot be empty")
+		}
+	}
+
+	var tone []string
+	if flags.Changed("tone") {
+		for _, entry := range opts.minimaxTone {
+			value := strings.TrimSpace(entry)
+			if value == "" {
+				return minimax.TTSRequest{}, errors.New("tone entries cannot be empty")
+			}
+			tone = append(tone, value)
+		}
+	}
+
+	var voiceModify *minimax.VoiceModify
+	var voiceModifyPitch *int
+	var voiceModifyIntensity *int
+	var voiceModifyTimbre *int
+	var voiceModifySoundEffects *string
+	if flags.Changed("voice-modify-pitch") {
+		if opts.minimaxVoiceModifyPitch < -100 || opts.minimaxVoiceModifyPitch > 100 {
+			return minimax.TTSRequest{}, errors.New("voice-modify-pitch must be between -100 and 100")
+		}
+		v := opts.minimaxVoiceModifyPitch
+		voiceModifyPitch = &v
+	}
+	if flags.Changed("voice-modify-intensity") {
+		if opts.minimaxVoiceModifyIntensity < -100 || opts.minimaxVoiceModifyIntensity > 100 {
+			return minimax.TTSRequest{}, errors.New("voice-modify-intensity must be between -100 and 100")
+		}
+		v := opts.minimaxVoiceModifyIntensity
+		voiceModifyIntensity = &v
+	}
+	if flags.Changed("voice-modify-timbre") {
+		if opts.minimaxVoiceModifyTimbre < -100 || opts.minimaxVoiceModifyTimbre > 100 {
+			return minimax.TTSRequest{}, errors.New("voice-modify-timbre must be between -100 and 100")
+		}
+		v := opts.minimaxVoiceModifyTimbre
+		voiceModifyTimbre = &v
+	}
+	if flags.Changed("voice-modify-sound-effects") {
+		value := strings.TrimSpace(opts.minimaxVoiceModifySoundEffects)
+		if value == "" {
+			return minimax.TTSRequest{}, errors.New("voice-modify-sound-effects cannot be empty")
+		}
+		voiceModifySoundEffects = &value
+	}
+	if voiceModifyPitch != nil || voiceModifyIntensity != nil || voiceModifyTimbre != nil || voiceModifySoundEffects != nil {
+		voiceModify = &minimax.VoiceModify{
+			Pitch:        voiceModifyPitch,
+			Intensity:    voiceModifyIntensity,
+			Timbre:       voiceModifyTimbre,
+			SoundEffects: voiceModifySoundEffects,
+		}
+	}
+
+	for _, r := range opts.localLexicon.Replacements(text) {
+		if strings.Contains(r.Text, "/") || strings.Contains(r.Replace, "/") {
+			// pronunciation_dict entries are "text/replacement", so a slash cannot be escaped.
+			fmt.Fprintf(os.Stderr, "warning: skipping lexicon rule %q -> %q: MiniMax pronunciation entries cannot contain \"/\"\n", r.Text, r.Replace)
+			continue
+		}
+		tone = append(tone, r.Text+"/"+r.Replace)
+	}
+
+	var pronunciationDict *minimax.PronunciationDict
+	if len(tone) > 0 {
+		pronunciationDict = &minimax.PronunciationDict{Tone: tone}
+	}
+
+	return minimax.TTSRequest{
+		Model:             opts.modelID,
+		Text:              text,
+		Speed:             speedPtr,
+		Volume:            volumePtr,
+		Pitch:             pitchPtr,
+		Emotion:           emotion,
+		TextNormalization: textNormPtr,
+		LatexRead:         latexReadPtr,
+		AudioFormat:       format,
+		LanguageBoost:     languageBoost,
+		ContinuousSound:   continuousSoundPtr,
+		PronunciationDict: pronunciationDict,
+		VoiceModify:       voiceModify,
+		SubtitleEnable:    opts.wantsTimings(),
+	}, nil
+}
+
+func normalizeMiniMaxFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "", "mp3", "wav", "flac":
+		if format == "" {
+			return "mp3", nil
+		}
+		return format, nil
+	case "mp3_44100_128":
+		return "mp3", nil
+	case "pcm_44100":
+		return "wav", nil
+	default:
+		if strings.HasPrefix(format, "mp3_") {
+			return "mp3", nil
+		}
+		if strings.HasPrefix(format, "pcm_") {
+			return "wav", nil
+		}
+		return "", fmt.Errorf("format %q not supported for MiniMax (use mp3, wav, flac)", format)
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/openai"
+
+	"github.com/spf13/cobra"
+)
+
+type openAIProvider struct {
+	client *openai.Client
+}
+
+func init() {
+	registerProvider(providerSpec{
+		name:              providerOpenAI,
+		voiceEnv:          "OPENAI_VOICE_ID",
+		defaultModel:      "gpt-4o-mini-tts",
+		models:            []modelInfo{{ID: "gpt-4o-mini-tts"}, {ID: "tts-1"}, {ID: "tts-1-hd"}},
+		passthroughModels: true,
+		charLimit:         4096,
+		ensureAPIKey:      ensureOpenAIAPIKey,
+		newProvider: func() ttsProvider {
+			return newOpenAIProvider(openai.NewClient(cfg.APIKey, openAIBaseURL()))
+		},
+	})
+}
+
+func newOpenAIProvider(client *openai.Client) *openAIProvider {
+	return &openAIProvider{client: client}
+}
+
+func (p *openAIProvider) Name() string { return providerOpenAI }
+
+func (p *openAIProvider) Capabilities() providerCapabilities {
+	return providerCapabilities{Streaming: true}
+}
+
+func (p *openAIProvider) ListVoices(ctx context.Context) ([]providerVoice, error) {
+	voices, err := p.client.ListVoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]providerVoice, 0, len(voices))
+	for _, v := range voices {
+		out = append(out, providerVoice{ID: v.VoiceID, Name: v.Name, Category: providerOpenAI})
+	}
+	return out, nil
+}
+
+func (p *openAIProvider) ResolveVoice(ctx context.Context, input string, forceID bool) (string, error) {
+	input = strings.TrimSpace(input)
+	if input != "" && forceID {
+		return input, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	voices, err := p.client.ListVoices(ctx)
+	if err != nil {
+		return "", err
+	}
+	if input == "" {
+		if len(voices) == 0 {
+			return "", errors.New("no voices available; specify --voice or set OPENAI_VOICE_ID")
+		}
+		fmt.Fprintf(os.Stderr, "defaulting to voice %s\n", voices[0].VoiceID)
+		return voices[0].VoiceID, nil
+	}
+	inputLower := strings.ToLower(input)
+	for _, v := range voices {
+		if strings.ToLower(v.VoiceID) == inputLower || strings.ToLower(v.Name) == inputLower {
+			return v.VoiceID, nil
+		}
+	}
+	// Servers accept voice blends and IDs they don't list (e.g. Kokoro "af_bella+af_sky").
+	return input, nil
+}
+
+func (p *openAIProvider) FormatForPath(path string) string {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".mp3", ".opus", ".aac", ".flac", ".wav":
+		return strings.TrimPrefix(ext, ".")
+	case ".wave":
+		return "wav"
+	default:
+		return ""
+	}
+}
+
+// PCMFormat names the rate of OpenAI's "pcm" output, which is always 24 kHz mono s16le.
+func (p *openAIProvider) PCMFormat(format string) string {
+	if strings.EqualFold(strings.TrimSpace(format), "pcm") {
+		return "pcm_24000"
+	}
+	return format
+}
+
+func (p *openAIProvider) BuildRequest(cmd *cobra.Command, opts speakOptions, text string) (ttsRequest, error) {
+	flags := cmd.Flags()
+
+	format := "mp3"
+	if flags.Changed("format") || opts.outputPath != "" {
+		var err error
+		if format, err = normalizeOpenAIFormat(opts.outputFmt); err != nil {
+			return ttsRequest{}, err
+		}
+	}
+	if opts.play && format != "mp3" && format != "wav" && format != "pcm" {
+		return ttsRequest{}, fmt.Errorf("playback supports mp3, wav, and pcm; use --output without --play for %s", format)
+	}
+
+	var speedPtr *float64
+	if flags.Changed("speed") || flags.Changed("rate") {
+		speed := opts.speed
+		speedPtr = &speed
+	}
+
+	return ttsRequest{voiceID: opts.voiceID, payload: openai.TTSRequest{
+		Model:          opts.modelID,
+		Input:          text,
+		ResponseFormat: format,
+		Speed:          speedPtr,
+	}}, nil
+}
+
+func (p *openAIProvider) Stream(ctx context.Context, req ttsRequest) (io.ReadCloser, error) {
+	payload, err := openAIPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.StreamTTS(ctx, req.voiceID, payload)
+}
+
+func (p *openAIProvider) Convert(ctx context.Context, req ttsRequest) ([]byte, error) {
+	payload, err := openAIPayload(req)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.ConvertTTS(ctx, req.voiceID, payload)
+}
+
+func openAIPayload(req ttsRequest) (openai.TTSRequest, error) {
+	payload, ok := req.payload.(openai.TTSRequest)
+	if !ok {
+		return openai.TTSRequest{}, fmt.Errorf("openai: unexpected request payload %T", req.payload)
+	}
+	return payload, nil
+}
+
+func normalizeOpenAIFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "", "mp3_44100_128":
+		return "mp3", nil
+	case "mp3", "opus", "aac", "flac", "wav", "pcm":
+		return format, nil
+	case "pcm_24000":
+		return "pcm", nil
+	default:
+		if strings.HasPrefix(format, "mp3_") {
+			return "mp3", nil
+		}
+		return "", fmt.Errorf("format %q not supported for OpenAI-compatible servers (use mp3, opus, aac, flac, wav, pcm)", format)
+	}
+}
+
+// openAIBaseURL returns the configured OpenAI-compatible server, or "" for api.openai.com.
+func openAIBaseURL() string {
+	for _, key := range []string{"SAG_OPENAI_BASE_URL", "OPENAI_BASE_URL"} {
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/openai"
+
+	"github.com/spf13/cobra"
+)
+
+func newOpenAITestCommand(t *testing.T, opts *speakOptions) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "speak"}
+	cmd.Flags().StringVar(&opts.outputFmt, "format", opts.outputFmt, "")
+	cmd.Flags().Float64Var(&opts.speed, "speed", opts.speed, "")
+	cmd.Flags().IntVar(&opts.rateWPM, "rate", 0, "")
+	return cmd
+}
+
+func TestOpenAIProviderConvertWritesOutput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["model"] != "kokoro" || got["voice"] != "af_bella" || got["response_format"] != "flac" {
+			t.Fatalf("unexpected payload: %v", got)
+		}
+		if _, ok := got["speed"]; ok {
+			t.Fatalf("expected speed to be omitted when unset, got %v", got["speed"])
+		}
+		_, _ = w.Write([]byte("flac-bytes"))
+	}))
+	defer srv.Close()
+
+	provider := newOpenAIProvider(openai.NewClient("", srv.URL+"/v1"))
+	out := filepath.Join(t.TempDir(), "out.flac")
+	opts := speakOptions{modelID: "kokoro", voiceID: "af_bella", outputPath: out, outputFmt: provider.FormatForPath(out), speed: 1}
+	req, err := provider.BuildRequest(newOpenAITestCommand(t, &opts), opts, "hello")
+	if err != nil {
+		t.Fatalf("BuildRequest error: %v", err)
+	}
+	if _, err := convertAndPlay(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("convertAndPlay error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "flac-bytes" {
+		t.Fatalf("unexpected output: %q", string(data))
+	}
+}
+
+func TestOpenAIProviderRejectsUnplayableFormat(t *testing.T) {
+	provider := newOpenAIProvider(openai.NewClient("", "http://invalid"))
+	opts := speakOptions{modelID: "tts-1", voiceID: "nova", outputFmt: "opus", play: true, speed: 1}
+	cmd := newOpenAITestCommand(t, &opts)
+	if err := cmd.Flags().Parse([]string{"--format", "opus"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	_, err := provider.BuildRequest(cmd, opts, "hi")
+	if err == nil || !strings.Contains(err.Error(), "playback supports mp3, wav, and pcm") {
+		t.Fatalf("expected playback format error, got %v", err)
+	}
+}
+
+func TestEnsureOpenAIAPIKeyOptionalForSelfHosted(t *testing.T) {
+	defer keepEnv(t)()
+	cfg.APIKey = ""
+	cfg.APIKeyFile = ""
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OPENAI_API_KEY_FILE", "")
+	t.Setenv("SAG_API_KEY", "")
+	t.Setenv("SAG_API_KEY_FILE", "")
+	t.Setenv("SAG_OPENAI_BASE_URL", "")
+	t.Setenv("OPENAI_BASE_URL", "")
+
+	if err := ensureOpenAIAPIKey(); err == nil || !strings.Contains(err.Error(), "missing OpenAI API key") {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+
+	t.Setenv("OPENAI_BASE_URL", "http://gpu-box:8880/v1")
+	if err := ensureOpenAIAPIKey(); err != nil {
+		t.Fatalf("expected self-hosted server to work without a key, got %v", err)
+	}
+}
+
+func TestOpenAIProviderPlaysBarePCM(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if got["response_format"] != "pcm" {
+			t.Fatalf("response_format = %v, want pcm", got["response_format"])
+		}
+		_, _ = w.Write([]byte{1, 0, 2, 0})
+	}))
+	defer srv.Close()
+
+	capture := filepath.Join(t.TempDir(), "capture.wav")
+	usePlayer(t, "wav-capture:"+capture)
+
+	provider := newOpenAIProvider(openai.NewClient("", srv.URL+"/v1"))
+	opts := speakOptions{modelID: "tts-1", voiceID: "nova", outputFmt: "pcm", play: true, speed: 1}
+	cmd := newOpenAITestCommand(t, &opts)
+	if err := cmd.Flags().Parse([]string{"--format", "pcm"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	applyOutputPath(cmd, provider, &opts)
+	req, err := provider.BuildRequest(cmd, opts, "hi")
+	if err != nil {
+		t.Fatalf("BuildRequest error: %v", err)
+	}
+	if _, err := convertAndPlay(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("convertAndPlay error: %v", err)
+	}
+
+	data, err := os.ReadFile(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 44+8 || binary.LittleEndian.Uint32(data[24:28]) != 24000 {
+		t.Fatalf("unexpected capture % x", data)
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+	"github.com/steipete/sag/internal/minimax"
+
+	"github.com/coder/websocket"
+)
+
+func TestLookupProviderKnown(t *testing.T) {
+	for _, name := range []string{providerElevenLabs, providerMiniMax, "MiniMax"} {
+		spec, err := lookupProvider(name)
+		if err != nil {
+			t.Fatalf("lookupProvider(%q) error: %v", name, err)
+		}
+		if spec.newProvider == nil || spec.ensureAPIKey == nil {
+			t.Fatalf("lookupProvider(%q) returned incomplete spec", name)
+		}
+	}
+}
+
+func TestLookupProviderUnknown(t *testing.T) {
+	_, err := lookupProvider("nope")
+	if err == nil || !strings.Contains(err.Error(), "unknown provider") || !strings.Contains(err.Error(), providerElevenLabs) {
+		t.Fatalf("expected unknown provider error listing providers, got %v", err)
+	}
+}
+
+func TestVoiceFromEnvPrefersProviderVariable(t *testing.T) {
+	t.Setenv("MINIMAX_VOICE_ID", "mini-voice")
+	t.Setenv("SAG_VOICE_ID", "sag-voice")
+	spec, err := lookupProvider(providerMiniMax)
+	if err != nil {
+		t.Fatalf("lookupProvider error: %v", err)
+	}
+	if got := voiceFromEnv(spec); got != "mini-voice" {
+		t.Fatalf("voiceFromEnv = %q, want mini-voice", got)
+	}
+
+	t.Setenv("MINIMAX_VOICE_ID", "")
+	if got := voiceFromEnv(spec); got != "sag-voice" {
+		t.Fatalf("voiceFromEnv fallback = %q, want sag-voice", got)
+	}
+}
+
+func TestStreamAndPlayMiniMaxProviderWritesOutput(t *testing.T) {
+	audioHex := hex.EncodeToString([]byte("mini-audio"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/t2a_v2" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"data\":{\"audio\":\"" + audioHex + "\",\"status\":1}}\n\n"))
+		_, _ = w.Write([]byte("data: {\"data\":{\"status\":2}}\n\n"))
+	}))
+	defer srv.Close()
+
+	provider := newMiniMaxProvider(minimax.NewClient("key", srv.URL))
+	out := t.TempDir() + "/out.mp3"
+	opts := speakOptions{voiceID: "v1", outputPath: out, stream: true}
+	req := ttsRequest{voiceID: "v1", payload: minimax.TTSRequest{Model: "speech-02-hd", Text: "hi"}}
+
+	if _, err := streamAndPlay(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("streamAndPlay error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "mini-audio" {
+		t.Fatalf("unexpected output data: %q", string(data))
+	}
+}
+
+func TestProviderRejectsForeignPayload(t *testing.T) {
+	provider := newMiniMaxProvider(minimax.NewClient("key", "http://invalid"))
+	_, err := provider.Convert(context.Background(), ttsRequest{voiceID: "v1", payload: "bogus"})
+	if err == nil || !strings.Contains(err.Error(), "unexpected request payload") {
+		t.Fatalf("expected payload type error, got %v", err)
+	}
+}
+
+func TestSelectProviderOrder(t *testing.T) {
+	t.Setenv("SAG_PROVIDER", "")
+	tests := []struct {
+		name     string
+		explicit string
+		env      string
+		modelID  string
+		want     string
+	}{
+		{"default", "", "", "", providerElevenLabs},
+		{"model prefix", "", "", "speech-02-hd", providerMiniMax},
+		{"env beats model", "", "elevenlabs", "speech-02-hd", providerElevenLabs},
+		{"flag beats env", "minimax", "elevenlabs", "", providerMiniMax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SAG_PROVIDER", tt.env)
+			spec, err := selectProvider(tt.explicit, tt.modelID)
+			if err != nil {
+				t.Fatalf("selectProvider error: %v", err)
+			}
+			if spec.name != tt.want {
+				t.Fatalf("selectProvider = %q, want %q", spec.name, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveModelDefaultsAndCanonicalizes(t *testing.T) {
+	spec, err := lookupProvider(providerMiniMax)
+	if err != nil {
+		t.Fatalf("lookupProvider error: %v", err)
+	}
+	got, err := spec.resolveModel("")
+	if err != nil || got != spec.defaultModel {
+		t.Fatalf("resolveModel(\"\") = %q, %v; want %q", got, err, spec.defaultModel)
+	}
+	got, err = spec.resolveModel("Speech-02-Turbo")
+	if err != nil || got != "speech-02-turbo" {
+		t.Fatalf("resolveModel canonical = %q, %v", got, err)
+	}
+}
+
+func TestResolveModelUnknownListsValid(t *testing.T) {
+	spec, err := lookupProvider(providerMiniMax)
+	if err != nil {
+		t.Fatalf("lookupProvider error: %v", err)
+	}
+	_, err = spec.resolveModel("eleven_v3")
+	if err == nil || !strings.Contains(err.Error(), "valid models:") || !strings.Contains(err.Error(), "speech-02-hd") {
+		t.Fatalf("expected unknown model error with catalog, got %v", err)
+	}
+}
+
+func TestElevenLabsStreamInputWritesOutput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-speech/v1/stream-input" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.CloseNow() }()
+		for {
+			_, data, err := conn.Read(r.Context())
+			if err != nil {
+				return
+			}
+			if string(data) == `{"text":""}` {
+				audio := base64.StdEncoding.EncodeToString([]byte("ws-audio"))
+				_ = conn.Write(r.Context(), websocket.MessageText, []byte(`{"audio":"`+audio+`"}`))
+				_ = conn.Write(r.Context(), websocket.MessageText, []byte(`{"isFinal":true}`))
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{ModelID: "eleven_flash_v2_5", OutputFormat: "mp3_44100_128"}}
+	resp, err := provider.StreamInput(context.Background(), req, strings.NewReader("hello there"), []int{50})
+	if err != nil {
+		t.Fatalf("StreamInput error: %v", err)
+	}
+	out := t.TempDir() + "/out.mp3"
+	if _, err := playStream(context.Background(), speakOptions{outputPath: out}, resp); err != nil {
+		t.Fatalf("playStream error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "ws-audio" {
+		t.Fatalf("unexpected output data: %q", string(data))
+	}
+}
+
+func TestElevenLabsStreamInputRejectsV3(t *testing.T) {
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", "http://invalid"))
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{ModelID: "eleven_v3"}}
+	_, err := provider.StreamInput(context.Background(), req, strings.NewReader("hi"), nil)
+	if err == nil || !strings.Contains(err.Error(), "eleven_v3") {
+		t.Fatalf("expected eleven_v3 rejection, got %v", err)
+	}
+}
+
+func TestStreamInputUnsupportedProvider(t *testing.T) {
+	var p ttsProvider = newMiniMaxProvider(minimax.NewClient("key", "http://invalid"))
+	if _, ok := p.(inputStreamer); ok {
+		t.Fatalf("minimax should not support --stream-input")
+	}
+}
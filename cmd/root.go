@@ -12,6 +12,7 @@ type rootConfig struct {
 	APIKey     string
 	APIKeyFile string
 	BaseURL    string
+	Player     string
 }
 
 var (
@@ -46,6 +47,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfg.APIKey, "api-key", "", "ElevenLabs API key (or ELEVENLABS_API_KEY)")
 	rootCmd.PersistentFlags().StringVar(&cfg.APIKeyFile, "api-key-file", "", "Read ElevenLabs API key from file (or ELEVENLABS_API_KEY_FILE)")
 	rootCmd.PersistentFlags().StringVar(&cfg.BaseURL, "base-url", "https://api.elevenlabs.io", "Override ElevenLabs API base URL")
+	rootCmd.PersistentFlags().StringVar(&cfg.Player, "player", "", "Audio output: oto (default), null, wav-capture:<file>, or a player command like paplay, aplay, ffplay, afplay (or SAG_PLAYER)")
 	rootCmd.PersistentFlags().BoolVarP(&versionFlag, "version", "V", false, "Print version and exit")
 }
 
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
+)
+
+type sfxOptions struct {
+	speakOptions
+	duration        float64
+	promptInfluence float64
+	loop            bool
+}
+
+func init() {
+	opts := sfxOptions{speakOptions: speakOptions{
+		outputFmt: "mp3_44100_128",
+		play:      true,
+	}}
+
+	cmd := &cobra.Command{
+		Use:   "sfx <description>",
+		Short: "Generate a sound effect from a text description (ElevenLabs)",
+		Example: "  sag sfx \"heavy wooden door creaks open\" --duration 3.5 --prompt-influence 0.4 -o door.mp3\n" +
+			"  sag sfx --loop --duration 10 \"rain on a tin roof\" -o rain.mp3",
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.TrimSpace(strings.Join(args, " "))
+			if text == "" {
+				return errors.New("sound description is empty")
+			}
+			req, err := buildSFXRequest(cmd, opts, text)
+			if err != nil {
+				return err
+			}
+
+			spec, err := lookupProvider(providerElevenLabs)
+			if err != nil {
+				return err
+			}
+			applyOutputPath(cmd, spec.newProvider(), &opts.speakOptions)
+			if err := checkPlayer(opts.play); err != nil {
+				return err
+			}
+			req.OutputFormat = opts.outputFmt
+
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
+			start := time.Now()
+			data, err := client.GenerateSoundEffect(ctx, req)
+			if err != nil {
+				return err
+			}
+			n, err := playData(ctx, opts.speakOptions, data)
+			if err != nil {
+				return err
+			}
+			if opts.metrics {
+				fmt.Fprintf(os.Stderr, "metrics: chars=%d bytes=%d dur=%s\n", len([]rune(text)), n, time.Since(start).Truncate(time.Millisecond))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64Var(&opts.duration, "duration", 0, "Length in seconds (0.5..30; default: chosen by the model)")
+	cmd.Flags().Float64Var(&opts.promptInfluence, "prompt-influence", 0, "How closely to follow the description (0..1; default 0.3)")
+	cmd.Flags().BoolVar(&opts.loop, "loop", false, "Generate a seamlessly looping sound")
+	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "Write audio to file (disables playback unless --play is also set)")
+	cmd.Flags().StringVar(&opts.outputFmt, "format", opts.outputFmt, "Output format (e.g. mp3_44100_128, pcm_44100)")
+	cmd.Flags().BoolVar(&opts.play, "play", opts.play, "Play audio through speakers")
+	cmd.Flags().BoolVar(&opts.metrics, "metrics", false, "Print request metrics to stderr")
+	rootCmd.AddCommand(cmd)
+}
+
+func buildSFXRequest(cmd *cobra.Command, opts sfxOptions, text string) (elevenlabs.SoundEffectRequest, error) {
+	req := elevenlabs.SoundEffectRequest{Text: text, Loop: opts.loop}
+	if cmd.Flags().Changed("duration") {
+		if opts.duration < 0.5 || opts.duration > 30 {
+			return req, errors.New("duration must be between 0.5 and 30 seconds")
+		}
+		d := opts.duration
+		req.DurationSeconds = &d
+	}
+	if cmd.Flags().Changed("prompt-influence") {
+		if opts.promptInfluence < 0 || opts.promptInfluence > 1 {
+			return req, errors.New("prompt-influence must be between 0 and 1")
+		}
+		p := opts.promptInfluence
+		req.PromptInfluence = &p
+	}
+	return req, nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSFXCommandWritesOutput(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sound-generation" || r.URL.Query().Get("output_format") != "mp3_44100_128" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		_, _ = w.Write([]byte("ID3door"))
+	}))
+	defer srv.Close()
+
+	out := filepath.Join(t.TempDir(), "door.mp3")
+	if _, err := executeRoot(t, srv.URL, "sfx", "heavy wooden door creaks open", "--duration", "3.5", "--prompt-influence", "0.4", "-o", out); err != nil {
+		t.Fatalf("sfx: %v", err)
+	}
+	if got["text"] != "heavy wooden door creaks open" || got["duration_seconds"] != 3.5 || got["prompt_influence"] != 0.4 {
+		t.Fatalf("unexpected payload %v", got)
+	}
+	if _, ok := got["loop"]; ok {
+		t.Fatalf("loop should be omitted: %v", got)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil || string(data) != "ID3door" {
+		t.Fatalf("unexpected output %q, %v", data, err)
+	}
+}
+
+func TestBuildSFXRequestValidates(t *testing.T) {
+	newCmd := func(args ...string) (*cobra.Command, sfxOptions) {
+		var opts sfxOptions
+		cmd := &cobra.Command{}
+		cmd.Flags().Float64Var(&opts.duration, "duration", 0, "")
+		cmd.Flags().Float64Var(&opts.promptInfluence, "prompt-influence", 0, "")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd, opts
+	}
+
+	cmd, opts := newCmd()
+	req, err := buildSFXRequest(cmd, opts, "boom")
+	if err != nil || req.DurationSeconds != nil || req.PromptInfluence != nil {
+		t.Fatalf("defaults should be omitted: %+v, %v", req, err)
+	}
+	for _, args := range [][]string{{"--duration", "0.2"}, {"--duration", "31"}, {"--prompt-influence", "1.5"}} {
+		cmd, opts := newCmd(args...)
+		if _, err := buildSFXRequest(cmd, opts, "boom"); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
@@ -7,18 +7,19 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	"github.com/steipete/sag/internal/audio"
 	"github.com/steipete/sag/internal/elevenlabs"
-	"github.com/steipete/sag/internal/minimax"
+	"github.com/steipete/sag/internal/lexicon"
 
 	"github.com/spf13/cobra"
 )
 
 type speakOptions struct {
+	provider    string
 	voiceID     string
 	modelID     string
 	outputPath  string
@@ -37,6 +38,17 @@ type speakOptions struct {
 	lang        string
 	metrics     bool
 
+	streamInput   bool
+	chunkSchedule []int
+	subtitlesPath string
+	alignmentPath string
+	chunkSize     int
+	async         bool
+	lexicons      []string
+	lexiconFiles  []string
+	// localLexicon holds the loaded --lexicon-file rules. MiniMax receives them as tone entries; other providers get rewritten text.
+	localLexicon *lexicon.Lexicon
+
 	speakerBoost   bool
 	noSpeakerBoost bool
 
@@ -57,11 +69,11 @@ type speakOptions struct {
 
 const defaultWPM = 175 // matches macOS `say` default rate
 
-var playToSpeakers = audio.StreamToSpeakers
-
 const (
 	providerElevenLabs = "elevenlabs"
 	providerMiniMax    = "minimax"
+	providerLocal      = "local"
+	providerOpenAI     = "openai"
 )
 
 func init() {
@@ -73,145 +85,186 @@ func init() {
 		speed:     1.0,
 	}
 
+	var spec providerSpec
 	cmd := &cobra.Command{
 		Use:   "speak [text]",
 		Short: "Speak the provided text using TTS (default: stream to speakers)",
 		Long:  "If no text argument is provided, the command reads from stdin.\n\nTip: run `sag prompting` for model-specific prompting tips and recommended flag combinations.",
 		Args:  cobra.ArbitraryArgs,
-		PreRunE: func(_ *cobra.Command, _ []string) error {
-			return ensureAPIKeyForProvider(detectProvider(opts.modelID))
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			var modelID string
+			if cmd.Flags().Changed("model-id") {
+				modelID = opts.modelID
+			}
+			var err error
+			spec, err = selectProvider(opts.provider, modelID)
+			if err != nil {
+				return err
+			}
+			if opts.modelID, err = spec.resolveModel(modelID); err != nil {
+				return err
+			}
+			return ensureAPIKeyForProvider(spec.name)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := applyRateAndSpeed(&opts); err != nil {
 				return err
 			}
 
-			provider := detectProvider(opts.modelID)
+			provider := spec.newProvider()
+
 			forceVoiceID := cmd.Flags().Changed("voice-id")
 			voiceInput := opts.voiceID
 			if voiceInput == "" {
-				if provider == providerMiniMax {
-					if env := os.Getenv("MINIMAX_VOICE_ID"); env != "" {
-						voiceInput = env
-						forceVoiceID = true
-					} else if env := os.Getenv("SAG_VOICE_ID"); env != "" {
-						voiceInput = env
-						forceVoiceID = true
-					}
-				} else {
-					if env := os.Getenv("ELEVENLABS_VOICE_ID"); env != "" {
-						voiceInput = env
-						forceVoiceID = true
-					} else if env := os.Getenv("SAG_VOICE_ID"); env != "" {
-						voiceInput = env
-						forceVoiceID = true
-					}
+				if env := voiceFromEnv(spec); env != "" {
+					voiceInput = env
+					forceVoiceID = true
 				}
 			}
-			elevenClient := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
-			miniClient := minimax.NewClient(cfg.APIKey, minimaxBaseURL())
+			if strings.TrimSpace(voiceInput) == "?" {
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				defer cancel()
+				return printProviderVoices(ctx, provider)
+			}
+			voiceID, err := provider.ResolveVoice(cmd.Context(), voiceInput, forceVoiceID)
+			if err != nil {
+				return err
+			}
+			opts.voiceID = voiceID
+
+			if len(opts.lexicons) > 0 && spec.name != providerElevenLabs {
+				return fmt.Errorf("--lexicon is not supported by %s", spec.name)
+			}
 
-			switch provider {
-			case providerMiniMax:
-				voiceID, err := resolveMiniMaxVoice(cmd.Context(), miniClient, voiceInput, forceVoiceID)
-				if err != nil {
+			var timer timingProvider
+			if opts.wantsTimings() {
+				if timer, err = timingProviderFor(provider, opts); err != nil {
 					return err
 				}
-				if voiceID == "" {
-					return nil
+			}
+			opts.lexiconFiles = lexiconFiles(cmd, opts.lexiconFiles)
+			if opts.streamInput {
+				if cmd.Flags().Changed("lexicon-file") {
+					return errors.New("--lexicon-file cannot be combined with --stream-input")
 				}
-				opts.voiceID = voiceID
-			default:
-				voiceID, err := resolveVoice(cmd.Context(), elevenClient, voiceInput, forceVoiceID)
-				if err != nil {
-					return err
+				if len(opts.lexiconFiles) > 0 {
+					fmt.Fprintln(os.Stderr, "warning: SAG_LEXICON_FILE rules are not applied with --stream-input")
 				}
-				if voiceID == "" {
-					// Likely printed voices for '?' request.
-					return nil
+				if opts.async {
+					return errors.New("--async cannot be combined with --stream-input")
 				}
-				opts.voiceID = voiceID
+				return runStreamInput(cmd, provider, opts, args)
 			}
 
+			if len(opts.lexiconFiles) > 0 {
+				if opts.localLexicon, err = loadLexiconFiles(opts.lexiconFiles); err != nil {
+					return err
+				}
+			}
 			text, err := resolveText(args, opts.inputFile)
 			if err != nil {
 				return err
 			}
+			if spec.name != providerMiniMax {
+				text = opts.localLexicon.Apply(text)
+			}
 
-			// If user provided output path with a known extension, infer a compatible format.
-			if opts.outputPath != "" {
-				if provider == providerMiniMax {
-					if inferred := inferMiniMaxFormatFromExt(opts.outputPath); inferred != "" {
-						opts.outputFmt = inferred
-					}
-				} else if inferred := inferFormatFromExt(opts.outputPath); inferred != "" {
-					opts.outputFmt = inferred
-				}
-				// Disable playback when -o is set, unless --play was explicitly provided
-				if !cmd.Flags().Changed("play") {
-					opts.play = false
+			applyOutputPath(cmd, provider, &opts)
+			if opts.async {
+				return runAsync(cmd, provider, opts, text)
+			}
+			if err := checkPlayer(opts.play); err != nil {
+				return err
+			}
+
+			chunks := splitForProvider(spec, opts, text)
+			if err := checkChunkable(opts, len(chunks)); err != nil {
+				return err
+			}
+			var req ttsRequest
+			if len(chunks) == 1 {
+				if req, err = provider.BuildRequest(cmd, opts, text); err != nil {
+					return err
 				}
+			} else {
+				fmt.Fprintf(os.Stderr, "splitting %d chars into %d requests\n", len([]rune(text)), len(chunks))
 			}
 
-			ctx, cancel := context.WithTimeout(cmd.Context(), 90*time.Second)
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(len(chunks))*90*time.Second)
 			defer cancel()
+			chars := len([]rune(text))
+			if spec.name == providerElevenLabs {
+				warnIfOverQuota(ctx, chars)
+			}
 
 			start := time.Now()
 			var bytes int64
-			switch provider {
-			case providerMiniMax:
-				payload, err := buildMiniMaxTTSRequest(cmd, opts, text)
-				if err != nil {
-					return err
-				}
-				if opts.stream {
-					n, err := streamAndPlayMiniMax(ctx, miniClient, opts, payload)
-					bytes = n
-					if err != nil {
-						return err
-					}
-				} else {
-					n, err := convertAndPlayMiniMax(ctx, miniClient, opts, payload)
-					bytes = n
-					if err != nil {
-						return err
-					}
+			switch {
+			case len(chunks) > 1:
+				var resp io.ReadCloser
+				if resp, err = synthesizeChunks(ctx, cmd, provider, opts, chunks); err == nil {
+					bytes, err = playStream(ctx, opts, resp)
 				}
+			case timer != nil:
+				bytes, err = speakWithTimings(ctx, timer, opts, req)
+			case opts.stream && provider.Capabilities().Streaming:
+				bytes, err = streamAndPlay(ctx, provider, opts, req)
 			default:
-				payload, err := buildTTSRequest(cmd, opts, text)
-				if err != nil {
-					return err
-				}
-				if opts.stream {
-					n, err := streamAndPlay(ctx, elevenClient, opts, payload)
-					bytes = n
-					if err != nil {
-						return err
-					}
-				} else {
-					n, err := convertAndPlay(ctx, elevenClient, opts, payload)
-					bytes = n
-					if err != nil {
-						return err
-					}
-				}
+				bytes, err = convertAndPlay(ctx, provider, opts, req)
+			}
+			if err != nil {
+				return err
+			}
+			if spec.name == providerElevenLabs {
+				recordQuotaUse(chars)
 			}
 			if opts.metrics {
 				fmt.Fprintf(os.Stderr, "metrics: chars=%d bytes=%d model=%s voice=%s stream=%t latencyTier=%d dur=%s\n",
-					len([]rune(text)), bytes, opts.modelID, opts.voiceID, opts.stream, opts.latencyTier, time.Since(start).Truncate(time.Millisecond))
+					chars, bytes, opts.modelID, opts.voiceID, opts.stream, opts.latencyTier, time.Since(start).Truncate(time.Millisecond))
+				if spec.name == providerElevenLabs {
+					printQuotaMetrics(ctx)
+				}
 			}
 			return nil
 		},
 	}
 
+	registerVoiceSettingFlags(cmd, &opts)
+	cmd.Flags().StringVar(&opts.provider, "provider", "", "TTS provider: elevenlabs|minimax|openai|local (or SAG_PROVIDER; default inferred from --model-id)")
 	cmd.Flags().StringVar(&opts.voiceID, "voice-id", "", "Voice ID to use (ELEVENLABS_VOICE_ID)")
 	cmd.Flags().StringVarP(&opts.voiceID, "voice", "v", "", "Alias for --voice-id; accepts name or ID; use '?' to list voices")
-	cmd.Flags().StringVar(&opts.modelID, "model-id", opts.modelID, "Model ID (default: eleven_v3). Common: eleven_multilingual_v2 (stable), eleven_flash_v2_5 (fast/cheap), eleven_turbo_v2_5 (balanced).")
+	cmd.Flags().StringVar(&opts.modelID, "model-id", opts.modelID, "Model ID (default per provider: eleven_v3 or speech-02-hd). Common: eleven_multilingual_v2 (stable), eleven_flash_v2_5 (fast/cheap), eleven_turbo_v2_5 (balanced).")
 	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "Write audio to file (disables playback unless --play is also set)")
 	cmd.Flags().StringVar(&opts.outputFmt, "format", opts.outputFmt, "Output format (e.g. mp3_44100_128)")
 	cmd.Flags().BoolVar(&opts.stream, "stream", opts.stream, "Stream audio while generating")
 	cmd.Flags().BoolVar(&opts.play, "play", opts.play, "Play audio through speakers")
 	cmd.Flags().IntVar(&opts.latencyTier, "latency-tier", 0, "Streaming latency tier (0=default,1-4 lower latency may cost more)")
+	cmd.Flags().BoolVar(&opts.metrics, "metrics", false, "Print request metrics to stderr (chars, bytes, duration, etc.)")
+	cmd.Flags().BoolVar(&opts.streamInput, "stream-input", false, "ElevenLabs: send text over a WebSocket as it arrives on stdin/--input-file, so speech starts before input ends")
+	cmd.Flags().IntSliceVar(&opts.chunkSchedule, "chunk-schedule", nil, "ElevenLabs --stream-input chunk_length_schedule in characters (e.g. 120,160,250,290)")
+	cmd.Flags().StringVar(&opts.subtitlesPath, "subtitles", "", "Write subtitles from speech timings (.srt or .vtt; ElevenLabs, MiniMax)")
+	cmd.Flags().StringVar(&opts.alignmentPath, "alignment", "", "Write word/character timings and request settings as JSON (ElevenLabs, MiniMax)")
+	cmd.Flags().IntVar(&opts.chunkSize, "chunk-size", 0, "Split long text into requests of at most this many characters (default: the model's limit)")
+	cmd.Flags().BoolVar(&opts.async, "async", false, "MiniMax: submit the text as a long-text job (up to 1M chars) and print its task ID; fetch with \"sag jobs wait\"")
+	cmd.Flags().StringArrayVar(&opts.lexicons, "lexicon", nil, "ElevenLabs pronunciation dictionary as name[@version] (repeatable, up to 3; see sag lexicon)")
+	cmd.Flags().StringArrayVar(&opts.lexiconFiles, "lexicon-file", nil, "Local pronunciation rules (.yaml or .pls) applied to the text on every provider (repeatable; or SAG_LEXICON_FILE)")
+	cmd.Flags().StringVarP(&opts.inputFile, "input-file", "f", "", "Read text from file (use '-' for stdin), matching macOS say -f")
+	cmd.Flags().Bool("progress", false, "Accepted for macOS say compatibility (no-op)")
+	cmd.Flags().String("network-send", "", "Accepted for macOS say compatibility (not implemented)")
+	cmd.Flags().String("audio-device", "", "Accepted for macOS say compatibility (not implemented)")
+	cmd.Flags().String("interactive", "", "Accepted for macOS say compatibility (not implemented)")
+	cmd.Flags().String("file-format", "", "Accepted for macOS say compatibility (not implemented)")
+	cmd.Flags().String("data-format", "", "Accepted for macOS say compatibility (not implemented)")
+	cmd.Flags().Int("channels", 0, "Accepted for macOS say compatibility (not implemented)")
+	cmd.Flags().Int("bit-rate", 0, "Accepted for macOS say compatibility (not implemented)")
+	cmd.Flags().Int("quality", 0, "Accepted for macOS say compatibility (not implemented)")
+
+	rootCmd.AddCommand(cmd)
+}
+
+// registerVoiceSettingFlags adds the flags that shape a synthesis request (speed, voice settings, MiniMax
+// voice options) so other commands build requests exactly like speak.
+func registerVoiceSettingFlags(cmd *cobra.Command, opts *speakOptions) {
 	cmd.Flags().Float64Var(&opts.speed, "speed", opts.speed, "Speech speed multiplier (e.g. 1.1 faster, 0.9 slower)")
 	cmd.Flags().IntVarP(&opts.rateWPM, "rate", "r", 0, "macOS say-style words-per-minute; overrides --speed when set (default 175 wpm)")
 	cmd.Flags().Float64Var(&opts.stability, "stability", 0, "Voice stability (0..1; higher = more consistent, less expressive)")
@@ -223,8 +276,6 @@ func init() {
 	cmd.Flags().Uint64Var(&opts.seed, "seed", 0, "Best-effort deterministic seed (0..4294967295; helps repeatability across runs)")
 	cmd.Flags().StringVar(&opts.normalize, "normalize", "", "Text normalization: auto|on|off (numbers/units/URLs; when set)")
 	cmd.Flags().StringVar(&opts.lang, "lang", "", "Language code (2-letter ISO 639-1; influences normalization; when set)")
-	cmd.Flags().BoolVar(&opts.metrics, "metrics", false, "Print request metrics to stderr (chars, bytes, duration, etc.)")
-	cmd.Flags().StringVarP(&opts.inputFile, "input-file", "f", "", "Read text from file (use '-' for stdin), matching macOS say -f")
 	cmd.Flags().Float64Var(&opts.minimaxVolume, "volume", 0, "MiniMax voice volume (0..10; when set)")
 	cmd.Flags().IntVar(&opts.minimaxPitch, "pitch", 0, "MiniMax voice pitch (-12..12; when set)")
 	cmd.Flags().StringVar(&opts.minimaxEmotion, "emotion", "", "MiniMax voice emotion (model dependent)")
@@ -238,17 +289,71 @@ func init() {
 	cmd.Flags().IntVar(&opts.minimaxVoiceModifyIntensity, "voice-modify-intensity", 0, "MiniMax voice modify intensity (-100..100; when set)")
 	cmd.Flags().IntVar(&opts.minimaxVoiceModifyTimbre, "voice-modify-timbre", 0, "MiniMax voice modify timbre (-100..100; when set)")
 	cmd.Flags().StringVar(&opts.minimaxVoiceModifySoundEffects, "voice-modify-sound-effects", "", "MiniMax voice modify sound effects (e.g. spacious_echo, auditorium_echo, lofi_telephone, robotic)")
-	cmd.Flags().Bool("progress", false, "Accepted for macOS say compatibility (no-op)")
-	cmd.Flags().String("network-send", "", "Accepted for macOS say compatibility (not implemented)")
-	cmd.Flags().String("audio-device", "", "Accepted for macOS say compatibility (not implemented)")
-	cmd.Flags().String("interactive", "", "Accepted for macOS say compatibility (not implemented)")
-	cmd.Flags().String("file-format", "", "Accepted for macOS say compatibility (not implemented)")
-	cmd.Flags().String("data-format", "", "Accepted for macOS say compatibility (not implemented)")
-	cmd.Flags().Int("channels", 0, "Accepted for macOS say compatibility (not implemented)")
-	cmd.Flags().Int("bit-rate", 0, "Accepted for macOS say compatibility (not implemented)")
-	cmd.Flags().Int("quality", 0, "Accepted for macOS say compatibility (not implemented)")
+}
 
-	rootCmd.AddCommand(cmd)
+// applyOutputPath infers the output format from -o and disables playback unless --play was explicitly provided.
+// Raw PCM formats get their sample rate spelled out so playback and .wav output know it.
+func applyOutputPath(cmd *cobra.Command, provider ttsProvider, opts *speakOptions) {
+	if namer, ok := provider.(rawFormatNamer); ok {
+		opts.outputFmt = namer.PCMFormat(opts.outputFmt)
+	}
+	if opts.outputPath == "" {
+		return
+	}
+	inferred := provider.FormatForPath(opts.outputPath)
+	// An explicit pcm_* --format keeps its sample rate when writing .wav.
+	keepPCM := cmd.Flags().Changed("format") && isWAVPath(opts.outputPath) && strings.HasPrefix(strings.ToLower(opts.outputFmt), "pcm_")
+	if inferred != "" && !keepPCM {
+		opts.outputFmt = inferred
+	}
+	if !cmd.Flags().Changed("play") {
+		opts.play = false
+	}
+}
+
+// runStreamInput speaks text incrementally as it is read, for providers that accept streamed input.
+func runStreamInput(cmd *cobra.Command, provider ttsProvider, opts speakOptions, args []string) error {
+	streamer, ok := provider.(inputStreamer)
+	if !ok {
+		return fmt.Errorf("--stream-input is not supported by %s", provider.Name())
+	}
+	src, err := openTextStream(args, opts.inputFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	applyOutputPath(cmd, provider, &opts)
+	if err := checkPlayer(opts.play); err != nil {
+		return err
+	}
+	req, err := provider.BuildRequest(cmd, opts, "")
+	if err != nil {
+		return err
+	}
+
+	var seen strings.Builder
+	// No overall timeout: input may keep arriving for as long as the upstream writer runs.
+	ctx := cmd.Context()
+	start := time.Now()
+	resp, err := streamer.StreamInput(ctx, req, io.TeeReader(src, &seen), opts.chunkSchedule)
+	if err != nil {
+		return err
+	}
+	bytes, err := playStream(ctx, opts, resp)
+	if err != nil {
+		return err
+	}
+	chars := len([]rune(seen.String()))
+	recordQuotaUse(chars)
+	if opts.metrics {
+		fmt.Fprintf(os.Stderr, "metrics: chars=%d bytes=%d model=%s voice=%s stream=input latencyTier=%d dur=%s\n",
+			chars, bytes, opts.modelID, opts.voiceID, opts.latencyTier, time.Since(start).Truncate(time.Millisecond))
+		printQuotaMetrics(ctx)
+	}
+	return nil
 }
 
 func applyRateAndSpeed(opts *speakOptions) error {
@@ -274,11 +379,6 @@ func buildTTSRequest(cmd *cobra.Command, opts speakOptions, text string) (eleven
 		if opts.stability < 0 || opts.stability > 1 {
 			return elevenlabs.TTSRequest{}, errors.New("stability must be between 0 and 1")
 		}
-		if opts.modelID == "eleven_v3" {
-			if !floatEqualsOneOf(opts.stability, []float64{0, 0.5, 1}) {
-				return elevenlabs.TTSRequest{}, errors.New("for eleven_v3, stability must be one of 0.0, 0.5, 1.0 (Creative/Natural/Robust)")
-			}
-		}
 		stabilityPtr = &opts.stability
 	}
 
@@ -344,6 +444,10 @@ func buildTTSRequest(cmd *cobra.Command, opts speakOptions, text string) (eleven
 		lang = ""
 	}
 
+	if err := validateElevenLabsModel(cmd, opts, text); err != nil {
+		return elevenlabs.TTSRequest{}, err
+	}
+
 	speed := opts.speed
 	return elevenlabs.TTSRequest{
 		Text:                   text,
@@ -398,6 +502,20 @@ func resolveText(args []string, inputFile string) (string, error) {
 	return readStdin()
 }
 
+// openTextStream returns the text source without buffering it, for incremental synthesis.
+func openTextStream(args []string, inputFile string) (io.ReadCloser, error) {
+	if inputFile != "" && inputFile != "-" {
+		return os.Open(inputFile)
+	}
+	if inputFile == "" && len(args) > 0 {
+		return io.NopCloser(strings.NewReader(strings.Join(args, " "))), nil
+	}
+	if isStdinTTY() {
+		return nil, errors.New("no text provided; pass text args, --input-file, or pipe input")
+	}
+	return io.NopCloser(os.Stdin), nil
+}
+
 func readStdin() (string, error) {
 	if isStdinTTY() {
 		return "", errors.New("no text provided; pass text args, --input-file, or pipe input")
@@ -421,11 +539,16 @@ func isStdinTTY() bool {
 	return (stat.Mode() & os.ModeCharDevice) != 0
 }
 
-func streamAndPlay(ctx context.Context, client *elevenlabs.Client, opts speakOptions, payload elevenlabs.TTSRequest) (int64, error) {
-	resp, err := client.StreamTTS(ctx, opts.voiceID, payload, opts.latencyTier)
+func streamAndPlay(ctx context.Context, provider ttsProvider, opts speakOptions, req ttsRequest) (int64, error) {
+	resp, err := provider.Stream(ctx, req)
 	if err != nil {
 		return 0, err
 	}
+	return playStream(ctx, opts, resp)
+}
+
+// playStream copies streamed audio to the output file and/or speakers, then closes it.
+func playStream(ctx context.Context, opts speakOptions, resp io.ReadCloser) (int64, error) {
 	defer func() {
 		_ = resp.Close()
 	}()
@@ -433,11 +556,8 @@ func streamAndPlay(ctx context.Context, client *elevenlabs.Client, opts speakOpt
 	writers := make([]io.Writer, 0, 2)
 	var file io.WriteCloser
 	if opts.outputPath != "" {
-		if err := os.MkdirAll(filepath.Dir(opts.outputPath), 0o755); err != nil {
-			return 0, err
-		}
-		file, err = os.Create(opts.outputPath)
-		if err != nil {
+		var err error
+		if file, err = createOutputFile(opts); err != nil {
 			return 0, err
 		}
 		defer func() {
@@ -460,13 +580,16 @@ func streamAndPlay(ctx context.Context, client *elevenlabs.Client, opts speakOpt
 			_ = pw.Close()
 		}()
 
-		playErr := playToSpeakers(ctx, pr)
+		playErr := playAudio(ctx, pr, opts.outputFmt)
 		copyNVal := <-copyN
 		copyErrVal := <-copyErr
 		if copyErrVal != nil {
 			return copyNVal, copyErrVal
 		}
-		return copyNVal, playErr
+		if playErr != nil {
+			return copyNVal, playErr
+		}
+		return copyNVal, closeOutputFile(file)
 	}
 
 	if len(writers) == 0 {
@@ -475,108 +598,76 @@ func streamAndPlay(ctx context.Context, client *elevenlabs.Client, opts speakOpt
 
 	mw := io.MultiWriter(writers...)
 	n, err := io.Copy(mw, resp)
-	return n, err
-}
-
-func convertAndPlay(ctx context.Context, client *elevenlabs.Client, opts speakOptions, payload elevenlabs.TTSRequest) (int64, error) {
-	data, err := client.ConvertTTS(ctx, opts.voiceID, payload)
 	if err != nil {
-		return 0, err
+		return n, err
 	}
-	n := int64(len(data))
+	return n, closeOutputFile(file)
+}
 
-	if opts.outputPath != "" {
-		if err := os.MkdirAll(filepath.Dir(opts.outputPath), 0o755); err != nil {
-			return n, err
-		}
-		if err := os.WriteFile(opts.outputPath, data, 0o644); err != nil {
-			return n, err
-		}
+// createOutputFile creates the -o file. Raw pcm_* audio written to a .wav path gets a WAV header.
+func createOutputFile(opts speakOptions) (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(opts.outputPath), 0o755); err != nil {
+		return nil, err
 	}
-
-	if opts.play {
-		pr, pw := io.Pipe()
-		go func() {
-			_, _ = pw.Write(data)
-			_ = pw.Close()
-		}()
-		return n, playToSpeakers(ctx, pr)
+	f, err := os.Create(opts.outputPath)
+	if err != nil {
+		return nil, err
 	}
-	if opts.outputPath == "" {
-		return n, errors.New("nothing to do: enable --play or provide --output")
+	if rate, ok := pcmRate(opts.outputFmt); ok && isWAVPath(opts.outputPath) {
+		return audio.NewWAVWriter(f, rate, 1), nil
 	}
-	return n, nil
+	return f, nil
 }
 
-func streamAndPlayMiniMax(ctx context.Context, client *minimax.Client, opts speakOptions, payload minimax.TTSRequest) (int64, error) {
-	resp, err := client.StreamTTS(ctx, opts.voiceID, payload)
-	if err != nil {
-		return 0, err
-	}
-	defer func() {
-		_ = resp.Close()
-	}()
-
-	writers := make([]io.Writer, 0, 2)
-	var file io.WriteCloser
-	if opts.outputPath != "" {
-		if err := os.MkdirAll(filepath.Dir(opts.outputPath), 0o755); err != nil {
-			return 0, err
-		}
-		file, err = os.Create(opts.outputPath)
-		if err != nil {
-			return 0, err
-		}
-		defer func() {
-			_ = file.Close()
-		}()
-		writers = append(writers, file)
+// closeOutputFile closes the -o file early so header and flush errors are reported.
+func closeOutputFile(file io.WriteCloser) error {
+	if file == nil {
+		return nil
 	}
+	return file.Close()
+}
 
-	if opts.play {
-		pr, pw := io.Pipe()
-		writers = append(writers, pw)
-		mw := io.MultiWriter(writers...)
-
-		copyErr := make(chan error, 1)
-		copyN := make(chan int64, 1)
-		go func() {
-			n, err := io.Copy(mw, resp)
-			copyN <- n
-			copyErr <- err
-			_ = pw.Close()
-		}()
-
-		playErr := playToSpeakers(ctx, pr)
-		copyNVal := <-copyN
-		copyErrVal := <-copyErr
-		if copyErrVal != nil {
-			return copyNVal, copyErrVal
-		}
-		return copyNVal, playErr
+// pcmRate returns the sample rate of a pcm_<rate> format.
+func pcmRate(format string) (int, bool) {
+	rate, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(format)), "pcm_")
+	if !ok {
+		return 0, false
 	}
+	n, err := strconv.Atoi(rate)
+	return n, err == nil && n > 0
+}
 
-	if len(writers) == 0 {
-		return 0, errors.New("nothing to do: enable --play or provide --output")
+func isWAVPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav", ".wave":
+		return true
+	default:
+		return false
 	}
-
-	mw := io.MultiWriter(writers...)
-	n, err := io.Copy(mw, resp)
-	return n, err
 }
 
-func convertAndPlayMiniMax(ctx context.Context, client *minimax.Client, opts speakOptions, payload minimax.TTSRequest) (int64, error) {
-	data, err := client.ConvertTTS(ctx, opts.voiceID, payload)
+func convertAndPlay(ctx context.Context, provider ttsProvider, opts speakOptions, req ttsRequest) (int64, error) {
+	data, err := provider.Convert(ctx, req)
 	if err != nil {
 		return 0, err
 	}
+	return playData(ctx, opts, data)
+}
+
+// playData writes fully downloaded audio to the output file and/or speakers.
+func playData(ctx context.Context, opts speakOptions, data []byte) (int64, error) {
 	n := int64(len(data))
 
 	if opts.outputPath != "" {
-		if err := os.MkdirAll(filepath.Dir(opts.outputPath), 0o755); err != nil {
+		file, err := createOutputFile(opts)
+		if err != nil {
+			return n, err
+		}
+		if _, err := file.Write(data); err != nil {
+			_ = file.Close()
 			return n, err
 		}
-		if err := os.WriteFile(opts.outputPath, data, 0o644); err != nil {
+		if err := file.Close(); err != nil {
 			return n, err
 		}
 	}
@@ -587,7 +678,7 @@ func convertAndPlayMiniMax(ctx context.Context, client *minimax.Client, opts spe
 			_, _ = pw.Write(data)
 			_ = pw.Close()
 		}()
-		return n, playToSpeakers(ctx, pr)
+		return n, playAudio(ctx, pr, opts.outputFmt)
 	}
 	if opts.outputPath == "" {
 		return n, errors.New("nothing to do: enable --play or provide --output")
@@ -610,29 +701,6 @@ func resolveVoice(ctx context.Context, client *elevenlabs.Client, voiceInput str
 		fmt.Fprintf(os.Stderr, "defaulting to voice %s (%s)\n", voices[0].Name, voices[0].VoiceID)
 		return voices[0].VoiceID, nil
 	}
-	if voiceInput == "?" {
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		voices, err := client.ListVoices(ctx)
-		if err != nil {
-			return "", err
-		}
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		if _, err := fmt.Fprintf(w, "VOICE ID\tNAME\tCATEGORY\tDESCRIPTION\n"); err != nil {
-			return "", err
-		}
-		for _, v := range voices {
-			desc := strings.ReplaceAll(v.Description, "\t", " ")
-			desc = strings.ReplaceAll(desc, "\n", " ")
-			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", v.VoiceID, v.Name, v.Category, desc); err != nil {
-				return "", err
-			}
-		}
-		if err := w.Flush(); err != nil {
-			return "", err
-		}
-		return "", nil
-	}
 
 	if forceID {
 		return voiceInput, nil
@@ -685,68 +753,6 @@ func resolveVoice(ctx context.Context, client *elevenlabs.Client, voiceInput str
 	return "", fmt.Errorf("voice %q not found; try 'sag voices' or -v '?'", voiceInput)
 }
 
-func resolveMiniMaxVoice(ctx context.Context, client *minimax.Client, voiceInput string, forceID bool) (string, error) {
-	voiceInput = strings.TrimSpace(voiceInput)
-	if voiceInput == "" {
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		voices, err := client.ListVoices(ctx)
-		if err != nil {
-			return "", fmt.Errorf("voice not specified and failed to fetch voices: %w", err)
-		}
-		if len(voices) == 0 {
-			return "", errors.New("no voices available; specify --voice or set MINIMAX_VOICE_ID")
-		}
-		fmt.Fprintf(os.Stderr, "defaulting to voice %s (%s)\n", voices[0].Name, voices[0].VoiceID)
-		return voices[0].VoiceID, nil
-	}
-	if voiceInput == "?" {
-		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-		voices, err := client.ListVoices(ctx)
-		if err != nil {
-			return "", err
-		}
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		if _, err := fmt.Fprintf(w, "VOICE ID\tNAME\tCATEGORY\n"); err != nil {
-			return "", err
-		}
-		for _, v := range voices {
-			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", v.VoiceID, v.Name, v.Category); err != nil {
-				return "", err
-			}
-		}
-		if err := w.Flush(); err != nil {
-			return "", err
-		}
-		return "", nil
-	}
-	if forceID {
-		return voiceInput, nil
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-	voices, err := client.ListVoices(ctx)
-	if err != nil {
-		return voiceInput, nil
-	}
-	voiceInputLower := strings.ToLower(voiceInput)
-	for _, v := range voices {
-		if strings.ToLower(v.VoiceID) == voiceInputLower || strings.ToLower(v.Name) == voiceInputLower {
-			fmt.Fprintf(os.Stderr, "using voice %s (%s)\n", v.Name, v.VoiceID)
-			return v.VoiceID, nil
-		}
-	}
-	for _, v := range voices {
-		if strings.Contains(strings.ToLower(v.Name), voiceInputLower) {
-			fmt.Fprintf(os.Stderr, "using voice %s (%s)\n", v.Name, v.VoiceID)
-			return v.VoiceID, nil
-		}
-	}
-	return voiceInput, nil
-}
-
 func looksLikeVoiceID(voiceInput string) bool {
 	return len(voiceInput) >= 15 && !strings.ContainsRune(voiceInput, ' ')
 }
@@ -772,219 +778,16 @@ func inferFormatFromExt(path string) string {
 	}
 }
 
-func inferMiniMaxFormatFromExt(path string) string {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".mp3":
-		return "mp3"
-	case ".wav", ".wave":
-		return "wav"
-	case ".flac":
-		return "flac"
-	default:
-		return ""
-	}
-}
-
 func detectProvider(modelID string) string {
 	modelID = strings.ToLower(strings.TrimSpace(modelID))
 	if strings.HasPrefix(modelID, "speech-") {
 		return providerMiniMax
 	}
-	return providerElevenLabs
-}
-
-func minimaxBaseURL() string {
-	host := strings.TrimSpace(os.Getenv("MINIMAX_API_HOST"))
-	if host == "" {
-		host = strings.TrimSpace(os.Getenv("MINIMAX_BASE_URL"))
-	}
-	if host == "" {
-		return ""
-	}
-	if strings.HasPrefix(host, "http://") || strings.HasPrefix(host, "https://") {
-		return host
-	}
-	return "https://" + host
-}
-
-func buildMiniMaxTTSRequest(cmd *cobra.Command, opts speakOptions, text string) (minimax.TTSRequest, error) {
-	flags := cmd.Flags()
-
-	format, err := normalizeMiniMaxFormat(opts.outputFmt)
-	if err != nil {
-		return minimax.TTSRequest{}, err
-	}
-	formatExplicit := flags.Changed("format") || opts.outputPath != ""
-	if formatExplicit {
-		if opts.stream && format != "mp3" {
-			return minimax.TTSRequest{}, errors.New("MiniMax streaming supports mp3 only; use --no-stream for wav/flac")
-		}
-		if opts.play && format != "mp3" {
-			return minimax.TTSRequest{}, errors.New("MiniMax playback supports mp3 only; use --output without --play for wav/flac")
-		}
-	} else {
-		format = ""
-	}
-
-	var speedPtr *float64
-	if flags.Changed("speed") || flags.Changed("rate") {
-		speed := opts.speed
-		speedPtr = &speed
+	if strings.HasPrefix(modelID, "tts-1") || strings.HasSuffix(modelID, "-tts") {
+		return providerOpenAI
 	}
-
-	var volumePtr *float64
-	if flags.Changed("volume") {
-		if opts.minimaxVolume <= 0 || opts.minimaxVolume > 10 {
-			return minimax.TTSRequest{}, errors.New("volume must be between 0 and 10 (exclusive 0)")
-		}
-		volume := opts.minimaxVolume
-		volumePtr = &volume
-	}
-
-	var pitchPtr *int
-	if flags.Changed("pitch") {
-		if opts.minimaxPitch < -12 || opts.minimaxPitch > 12 {
-			return minimax.TTSRequest{}, errors.New("pitch must be between -12 and 12")
-		}
-		pitch := opts.minimaxPitch
-		pitchPtr = &pitch
-	}
-
-	emotion := strings.TrimSpace(opts.minimaxEmotion)
-	if flags.Changed("emotion") && emotion == "" {
-		return minimax.TTSRequest{}, errors.New("emotion cannot be empty")
-	}
-
-	var textNormPtr *bool
-	if flags.Changed("text-normalization") {
-		v := opts.minimaxTextNormalization
-		textNormPtr = &v
-	}
-
-	var latexReadPtr *bool
-	if flags.Changed("latex-read") {
-		v := opts.minimaxLatexRead
-		latexReadPtr = &v
-	}
-
-	var continuousSoundPtr *bool
-	if flags.Changed("continuous-sound") {
-		v := opts.minimaxContinuousSound
-		continuousSoundPtr = &v
-	}
-
-	var languageBoost string
-	if flags.Changed("language") || flags.Changed("accent") {
-		lang := strings.TrimSpace(opts.minimaxLanguage)
-		accent := strings.TrimSpace(opts.minimaxAccent)
-		if lang != "" && accent != "" && lang != accent {
-			return minimax.TTSRequest{}, errors.New("choose only one of --language or --accent (or set the same value)")
-		}
-		if lang != "" {
-			languageBoost = lang
-		} else {
-			languageBoost = accent
-		}
-		if languageBoost == "" {
-			return minimax.TTSRequest{}, errors.New("language/accent cannot be empty")
-		}
-	}
-
-	var tone []string
-	if flags.Changed("tone") {
-		for _, entry := range opts.minimaxTone {
-			value := strings.TrimSpace(entry)
-			if value == "" {
-				return minimax.TTSRequest{}, errors.New("tone entries cannot be empty")
-			}
-			tone = append(tone, value)
-		}
-	}
-
-	var voiceModify *minimax.VoiceModify
-	var voiceModifyPitch *int
-	var voiceModifyIntensity *int
-	var voiceModifyTimbre *int
-	var voiceModifySoundEffects *string
-	if flags.Changed("voice-modify-pitch") {
-		if opts.minimaxVoiceModifyPitch < -100 || opts.minimaxVoiceModifyPitch > 100 {
-			return minimax.TTSRequest{}, errors.New("voice-modify-pitch must be between -100 and 100")
-		}
-		v := opts.minimaxVoiceModifyPitch
-		voiceModifyPitch = &v
-	}
-	if flags.Changed("voice-modify-intensity") {
-		if opts.minimaxVoiceModifyIntensity < -100 || opts.minimaxVoiceModifyIntensity > 100 {
-			return minimax.TTSRequest{}, errors.New("voice-modify-intensity must be between -100 and 100")
-		}
-		v := opts.minimaxVoiceModifyIntensity
-		voiceModifyIntensity = &v
-	}
-	if flags.Changed("voice-modify-timbre") {
-		if opts.minimaxVoiceModifyTimbre < -100 || opts.minimaxVoiceModifyTimbre > 100 {
-			return minimax.TTSRequest{}, errors.New("voice-modify-timbre must be between -100 and 100")
-		}
-		v := opts.minimaxVoiceModifyTimbre
-		voiceModifyTimbre = &v
-	}
-	if flags.Changed("voice-modify-sound-effects") {
-		value := strings.TrimSpace(opts.minimaxVoiceModifySoundEffects)
-		if value == "" {
-			return minimax.TTSRequest{}, errors.New("voice-modify-sound-effects cannot be empty")
-		}
-		voiceModifySoundEffects = &value
-	}
-	if voiceModifyPitch != nil || voiceModifyIntensity != nil || voiceModifyTimbre != nil || voiceModifySoundEffects != nil {
-		voiceModify = &minimax.VoiceModify{
-			Pitch:        voiceModifyPitch,
-			Intensity:    voiceModifyIntensity,
-			Timbre:       voiceModifyTimbre,
-			SoundEffects: voiceModifySoundEffects,
-		}
-	}
-
-	var pronunciationDict *minimax.PronunciationDict
-	if len(tone) > 0 {
-		pronunciationDict = &minimax.PronunciationDict{Tone: tone}
-	}
-
-	return minimax.TTSRequest{
-		Model:             opts.modelID,
-		Text:              text,
-		Speed:             speedPtr,
-		Volume:            volumePtr,
-		Pitch:             pitchPtr,
-		Emotion:           emotion,
-		TextNormalization: textNormPtr,
-		LatexRead:         latexReadPtr,
-		AudioFormat:       format,
-		LanguageBoost:     languageBoost,
-		ContinuousSound:   continuousSoundPtr,
-		PronunciationDict: pronunciationDict,
-		VoiceModify:       voiceModify,
-	}, nil
-}
-
-func normalizeMiniMaxFormat(format string) (string, error) {
-	format = strings.ToLower(strings.TrimSpace(format))
-	switch format {
-	case "", "mp3", "wav", "flac":
-		if format == "" {
-			return "mp3", nil
-		}
-		return format, nil
-	case "mp3_44100_128":
-		return "mp3", nil
-	case "pcm_44100":
-		return "wav", nil
-	default:
-		if strings.HasPrefix(format, "mp3_") {
-			return "mp3", nil
-		}
-		if strings.HasPrefix(format, "pcm_") {
-			return "wav", nil
-		}
-		return "", fmt.Errorf("format %q not supported for MiniMax (use mp3, wav, flac)", format)
+	if modelID == providerLocal {
+		return providerLocal
 	}
+	return providerElevenLabs
 }
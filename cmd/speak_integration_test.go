@@ -15,7 +15,13 @@ func TestSpeakCommand_FlagsBuildRequestAndMetrics(t *testing.T) {
 
 	const voiceID = "abc1234567890123"
 
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/user/subscription" {
+			_, _ = w.Write([]byte(`{"tier":"creator","character_count":99995,"character_limit":100000}`))
+			return
+		}
 		if !strings.Contains(r.URL.Path, "/v1/text-to-speech/") {
 			t.Fatalf("unexpected path: %s", r.URL.Path)
 		}
@@ -103,4 +109,7 @@ func TestSpeakCommand_FlagsBuildRequestAndMetrics(t *testing.T) {
 	if !strings.Contains(stderr, "metrics: chars=") || !strings.Contains(stderr, "bytes=") || !strings.Contains(stderr, "dur=") {
 		t.Fatalf("expected metrics output, got %q", stderr)
 	}
+	if !strings.Contains(stderr, "only 5 remain") || !strings.Contains(stderr, "metrics: quota remaining=5 limit=100000") {
+		t.Fatalf("expected quota warning and metrics, got %q", stderr)
+	}
 }
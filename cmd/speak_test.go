@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"context"
+	"encoding/binary"
 	"io"
 	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
 )
 
 func TestInferFormatFromExt(t *testing.T) {
@@ -31,6 +35,65 @@ func TestInferFormatFromExt(t *testing.T) {
 	}
 }
 
+func TestApplyOutputPathKeepsExplicitPCMRate(t *testing.T) {
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", "http://invalid"))
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{nil, "pcm_44100"},
+		{[]string{"--format", "pcm_24000"}, "pcm_24000"},
+		{[]string{"--format", "mp3_44100_128"}, "pcm_44100"},
+	} {
+		opts := speakOptions{outputPath: "out.wav", outputFmt: "mp3_44100_128", play: true}
+		cmd := &cobra.Command{}
+		cmd.Flags().StringVar(&opts.outputFmt, "format", opts.outputFmt, "")
+		cmd.Flags().BoolVar(&opts.play, "play", opts.play, "")
+		if err := cmd.Flags().Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		applyOutputPath(cmd, provider, &opts)
+		if opts.outputFmt != tt.want || opts.play {
+			t.Fatalf("%v: format=%q play=%t, want %q without playback", tt.args, opts.outputFmt, opts.play, tt.want)
+		}
+	}
+}
+
+func TestPCMOutputToWAVGetsHeader(t *testing.T) {
+	dir := t.TempDir()
+	pcm := []byte{1, 0, 2, 0, 3, 0, 4, 0}
+	for name, write := range map[string]func(speakOptions) error{
+		"stream.wav": func(opts speakOptions) error {
+			_, err := playStream(context.Background(), opts, io.NopCloser(strings.NewReader(string(pcm))))
+			return err
+		},
+		"data.wav": func(opts speakOptions) error {
+			_, err := playData(context.Background(), opts, pcm)
+			return err
+		},
+	} {
+		path := filepath.Join(dir, name)
+		if err := write(speakOptions{outputPath: path, outputFmt: "pcm_22050"}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) != 44+len(pcm) || string(data[:4]) != "RIFF" || binary.LittleEndian.Uint32(data[24:28]) != 22050 || binary.LittleEndian.Uint32(data[40:44]) != uint32(len(pcm)) {
+			t.Fatalf("%s: not a valid 22.05 kHz WAV: % x", name, data[:min(len(data), 44)])
+		}
+	}
+
+	raw := filepath.Join(dir, "raw.pcm")
+	if _, err := playData(context.Background(), speakOptions{outputPath: raw, outputFmt: "pcm_22050"}, pcm); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(raw); string(data) != string(pcm) {
+		t.Fatalf("non-.wav output should stay raw, got % x", data)
+	}
+}
+
 func TestResolveTextFromArgs(t *testing.T) {
 	got, err := resolveText([]string{"hello", "world"}, "")
 	if err != nil {
@@ -117,6 +180,31 @@ func TestResolveTextEmptyFile(t *testing.T) {
 	}
 }
 
+func TestOpenTextStreamSources(t *testing.T) {
+	src, err := openTextStream([]string{"hello", "world"}, "")
+	if err != nil {
+		t.Fatalf("openTextStream args error: %v", err)
+	}
+	data, _ := io.ReadAll(src)
+	if string(data) != "hello world" {
+		t.Fatalf("openTextStream args = %q", string(data))
+	}
+
+	path := t.TempDir() + "/in.txt"
+	if err := os.WriteFile(path, []byte("from file"), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	src, err = openTextStream([]string{"ignored"}, path)
+	if err != nil {
+		t.Fatalf("openTextStream file error: %v", err)
+	}
+	defer func() { _ = src.Close() }()
+	data, _ = io.ReadAll(src)
+	if string(data) != "from file" {
+		t.Fatalf("openTextStream file = %q", string(data))
+	}
+}
+
 func TestApplyRateOverridesInvalidSpeed(t *testing.T) {
 	opts := &speakOptions{speed: 0.3, rateWPM: 200}
 	if err := applyRateAndSpeed(opts); err != nil {
@@ -277,7 +365,7 @@ func TestResolveVoicePartialMatch(t *testing.T) {
 	}
 }
 
-func TestResolveVoiceListOutputsTable(t *testing.T) {
+func TestPrintProviderVoicesOutputsTable(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		if _, err := w.Write([]byte(`{"voices":[{"voice_id":"id1","name":"Alpha","category":"premade"}]}`)); err != nil {
 			t.Fatalf("write response: %v", err)
@@ -288,13 +376,9 @@ func TestResolveVoiceListOutputsTable(t *testing.T) {
 	restore, read := captureStdout(t)
 	defer restore()
 
-	client := elevenlabs.NewClient("key", srv.URL)
-	id, err := resolveVoice(context.Background(), client, "?", false)
-	if err != nil {
-		t.Fatalf("resolveVoice error: %v", err)
-	}
-	if id != "" {
-		t.Fatalf("expected empty ID when listing voices, got %q", id)
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+	if err := printProviderVoices(context.Background(), provider); err != nil {
+		t.Fatalf("printProviderVoices error: %v", err)
 	}
 	if out := read(); !strings.Contains(out, "VOICE ID") || !strings.Contains(out, "Alpha") {
 		t.Fatalf("expected table output, got %q", out)
@@ -314,9 +398,9 @@ func TestStreamAndPlayWritesOutput(t *testing.T) {
 	tmp := t.TempDir()
 	out := tmp + "/out.mp3"
 	opts := speakOptions{voiceID: "v1", outputPath: out, stream: true, play: false}
-	payload := elevenlabs.TTSRequest{Text: "hi"}
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "hi"}}
 
-	if _, err := streamAndPlay(context.Background(), client, opts, payload); err != nil {
+	if _, err := streamAndPlay(context.Background(), newElevenLabsProvider(client), opts, req); err != nil {
 		t.Fatalf("streamAndPlay error: %v", err)
 	}
 	data, err := os.ReadFile(out)
@@ -341,9 +425,9 @@ func TestConvertAndPlayWritesOutput(t *testing.T) {
 	tmp := t.TempDir()
 	out := tmp + "/out.mp3"
 	opts := speakOptions{voiceID: "v1", outputPath: out, play: false}
-	payload := elevenlabs.TTSRequest{Text: "hi"}
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "hi"}}
 
-	if _, err := convertAndPlay(context.Background(), client, opts, payload); err != nil {
+	if _, err := convertAndPlay(context.Background(), newElevenLabsProvider(client), opts, req); err != nil {
 		t.Fatalf("convertAndPlay error: %v", err)
 	}
 	data, err := os.ReadFile(out)
@@ -358,9 +442,9 @@ func TestConvertAndPlayWritesOutput(t *testing.T) {
 func TestStreamAndPlayRequiresWork(t *testing.T) {
 	client := elevenlabs.NewClient("key", "http://invalid")
 	opts := speakOptions{voiceID: "v1", play: false, stream: true}
-	payload := elevenlabs.TTSRequest{Text: "hi"}
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "hi"}}
 
-	_, err := streamAndPlay(context.Background(), client, opts, payload)
+	_, err := streamAndPlay(context.Background(), newElevenLabsProvider(client), opts, req)
 	if err == nil {
 		t.Fatalf("expected error when no output and play disabled")
 	}
@@ -383,9 +467,9 @@ func TestStreamAndPlayWithPlayback(t *testing.T) {
 
 	client := elevenlabs.NewClient("key", srv.URL)
 	opts := speakOptions{voiceID: "v1", play: true, stream: true}
-	payload := elevenlabs.TTSRequest{Text: "hi"}
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "hi"}}
 
-	if _, err := streamAndPlay(context.Background(), client, opts, payload); err != nil {
+	if _, err := streamAndPlay(context.Background(), newElevenLabsProvider(client), opts, req); err != nil {
 		t.Fatalf("streamAndPlay error: %v", err)
 	}
 	if !called {
@@ -393,6 +477,25 @@ func TestStreamAndPlayWithPlayback(t *testing.T) {
 	}
 }
 
+func TestPlayStreamPassesOutputFormat(t *testing.T) {
+	orig := playAudio
+	defer func() { playAudio = orig }()
+	var format string
+	playAudio = func(_ context.Context, r io.Reader, f string) error {
+		format = f
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}
+
+	opts := speakOptions{play: true, outputFmt: "pcm_24000"}
+	if _, err := playStream(context.Background(), opts, io.NopCloser(strings.NewReader("\x00\x00"))); err != nil {
+		t.Fatalf("playStream error: %v", err)
+	}
+	if format != "pcm_24000" {
+		t.Fatalf("playback got format %q, want pcm_24000", format)
+	}
+}
+
 func TestConvertAndPlayWithPlayback(t *testing.T) {
 	called := false
 	restore := stubPlay(t, func(data []byte) {
@@ -410,9 +513,9 @@ func TestConvertAndPlayWithPlayback(t *testing.T) {
 
 	client := elevenlabs.NewClient("key", srv.URL)
 	opts := speakOptions{voiceID: "v1", play: true, outputPath: "", stream: false}
-	payload := elevenlabs.TTSRequest{Text: "hi"}
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "hi"}}
 
-	if _, err := convertAndPlay(context.Background(), client, opts, payload); err != nil {
+	if _, err := convertAndPlay(context.Background(), newElevenLabsProvider(client), opts, req); err != nil {
 		t.Fatalf("convertAndPlay error: %v", err)
 	}
 	if !called {
@@ -476,11 +579,11 @@ func TestResolveVoiceByName(t *testing.T) {
 
 func stubPlay(t *testing.T, fn func([]byte)) func() {
 	t.Helper()
-	orig := playToSpeakers
-	playToSpeakers = func(_ context.Context, r io.Reader) error {
+	orig := playAudio
+	playAudio = func(_ context.Context, r io.Reader, _ string) error {
 		b, _ := io.ReadAll(r)
 		fn(b)
 		return nil
 	}
-	return func() { playToSpeakers = orig }
+	return func() { playAudio = orig }
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steipete/sag/internal/subtitles"
+)
+
+// wantsTimings reports whether --subtitles or --alignment needs timing data from the provider.
+func (o speakOptions) wantsTimings() bool {
+	return o.subtitlesPath != "" || o.alignmentPath != ""
+}
+
+// timingProviderFor checks that --subtitles/--alignment can be honored before any request is sent.
+func timingProviderFor(provider ttsProvider, opts speakOptions) (timingProvider, error) {
+	flag := "--alignment"
+	if opts.subtitlesPath != "" {
+		flag = "--subtitles"
+		if _, err := subtitleFormat(opts.subtitlesPath); err != nil {
+			return nil, err
+		}
+	}
+	if opts.streamInput {
+		return nil, fmt.Errorf("%s cannot be combined with --stream-input", flag)
+	}
+	timer, ok := provider.(timingProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s is not supported by %s", flag, provider.Name())
+	}
+	return timer, nil
+}
+
+func subtitleFormat(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".srt", ".vtt":
+		return ext[1:], nil
+	default:
+		return "", fmt.Errorf("unsupported subtitles extension %q (use .srt or .vtt)", filepath.Ext(path))
+	}
+}
+
+// speakWithTimings plays/saves audio like streamAndPlay or convertAndPlay, then writes the subtitles and alignment files.
+func speakWithTimings(ctx context.Context, timer timingProvider, opts speakOptions, req ttsRequest) (int64, error) {
+	var (
+		n       int64
+		timings subtitles.Timings
+	)
+	if opts.stream {
+		resp, err := timer.StreamTimed(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		if n, err = playStream(ctx, opts, resp); err != nil {
+			return n, err
+		}
+		timings = resp.Timings()
+	} else {
+		data, t, err := timer.ConvertTimed(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+		if n, err = playData(ctx, opts, data); err != nil {
+			return n, err
+		}
+		timings = t
+	}
+	if opts.subtitlesPath != "" {
+		if err := writeSubtitles(opts.subtitlesPath, timings); err != nil {
+			return n, err
+		}
+	}
+	if opts.alignmentPath != "" {
+		if err := writeAlignment(opts.alignmentPath, timer.Name(), opts, req, timings); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func writeSubtitles(path string, timings subtitles.Timings) error {
+	format, err := subtitleFormat(path)
+	if err != nil {
+		return err
+	}
+	cues := subtitles.BuildCues(timings.AllWords(), subtitles.DefaultCueOptions)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if format == "vtt" {
+		err = subtitles.WriteVTT(f, cues)
+	} else {
+		err = subtitles.WriteSRT(f, cues)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
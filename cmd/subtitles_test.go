@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+	"github.com/steipete/sag/internal/minimax"
+	"github.com/steipete/sag/internal/openai"
+	"github.com/steipete/sag/internal/subtitles"
+)
+
+const timestampsJSON = `"alignment":{"characters":["H","i",".", " ","B","y","e"],` +
+	`"character_start_times_seconds":[0,0.1,0.2,0.3,0.4,0.5,0.6],` +
+	`"character_end_times_seconds":[0.1,0.2,0.3,0.4,0.5,0.6,0.7]}`
+
+func TestSpeakWithSubtitlesStreamWritesSRT(t *testing.T) {
+	audio := base64.StdEncoding.EncodeToString([]byte("audio"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-speech/v1/stream/with-timestamps" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"audio_base64":"` + audio + `",` + timestampsJSON + "}\n"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	opts := speakOptions{outputPath: filepath.Join(dir, "out.mp3"), subtitlesPath: filepath.Join(dir, "out.srt"), stream: true}
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "Hi. Bye"}}
+
+	if _, err := speakWithTimings(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("speakWithTimings error: %v", err)
+	}
+	data, err := os.ReadFile(opts.outputPath)
+	if err != nil || string(data) != "audio" {
+		t.Fatalf("unexpected audio output: %q, %v", string(data), err)
+	}
+	srt, err := os.ReadFile(opts.subtitlesPath)
+	if err != nil {
+		t.Fatalf("read subtitles: %v", err)
+	}
+	want := "1\n00:00:00,000 --> 00:00:00,300\nHi.\n\n2\n00:00:00,400 --> 00:00:00,700\nBye\n\n"
+	if string(srt) != want {
+		t.Fatalf("unexpected SRT:\n%s", srt)
+	}
+}
+
+func TestSpeakWithSubtitlesConvertWritesVTT(t *testing.T) {
+	audio := base64.StdEncoding.EncodeToString([]byte("audio"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-speech/v1/with-timestamps" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"audio_base64":"` + audio + `",` + timestampsJSON + "}"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	opts := speakOptions{outputPath: filepath.Join(dir, "out.mp3"), subtitlesPath: filepath.Join(dir, "out.vtt")}
+	provider := newElevenLabsProvider(elevenlabs.NewClient("key", srv.URL))
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "Hi. Bye"}}
+
+	if _, err := speakWithTimings(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("speakWithTimings error: %v", err)
+	}
+	vtt, err := os.ReadFile(opts.subtitlesPath)
+	if err != nil {
+		t.Fatalf("read subtitles: %v", err)
+	}
+	if !strings.HasPrefix(string(vtt), "WEBVTT\n\n00:00:00.000 --> 00:00:00.300\nHi.") {
+		t.Fatalf("unexpected VTT:\n%s", vtt)
+	}
+}
+
+func TestTimingProviderForValidates(t *testing.T) {
+	eleven := newElevenLabsProvider(elevenlabs.NewClient("key", "http://invalid"))
+	if _, err := timingProviderFor(eleven, speakOptions{subtitlesPath: "out.txt"}); err == nil || !strings.Contains(err.Error(), ".srt or .vtt") {
+		t.Fatalf("expected extension error, got %v", err)
+	}
+	oai := newOpenAIProvider(openai.NewClient("key", "http://invalid"))
+	if _, err := timingProviderFor(oai, speakOptions{alignmentPath: "out.json"}); err == nil || !strings.Contains(err.Error(), "--alignment is not supported by openai") {
+		t.Fatalf("expected unsupported provider error, got %v", err)
+	}
+	if _, err := timingProviderFor(eleven, speakOptions{subtitlesPath: "out.vtt"}); err != nil {
+		t.Fatalf("timingProviderFor error: %v", err)
+	}
+}
+
+func TestSpeakWithTimingsMiniMaxWritesAlignment(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/t2a_v2":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"subtitle_enable":true`) {
+				t.Errorf("expected subtitle_enable in request: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"data":{"audio":"` + hex.EncodeToString([]byte("mini")) + `","subtitle_file":"` + srv.URL + `/subs.json"},"base_resp":{"status_code":0}}`))
+		case "/subs.json":
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("subtitle download must not send the API key")
+			}
+			_, _ = w.Write([]byte(`[{"text":"Hello there.","time_begin":0,"time_end":1200}]`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	opts := speakOptions{modelID: "speech-02-hd", outputPath: filepath.Join(dir, "out.mp3"), alignmentPath: filepath.Join(dir, "out.json"), stream: true}
+	provider := newMiniMaxProvider(minimax.NewClient("key", srv.URL))
+	req := ttsRequest{voiceID: "v1", payload: minimax.TTSRequest{Model: "speech-02-hd", Text: "Hello there.", SubtitleEnable: true}}
+
+	if _, err := speakWithTimings(context.Background(), provider, opts, req); err != nil {
+		t.Fatalf("speakWithTimings error: %v", err)
+	}
+	if data, _ := os.ReadFile(opts.outputPath); string(data) != "mini" {
+		t.Fatalf("unexpected audio output: %q", string(data))
+	}
+	raw, err := os.ReadFile(opts.alignmentPath)
+	if err != nil {
+		t.Fatalf("read alignment: %v", err)
+	}
+	var got struct {
+		Provider string `json:"provider"`
+		VoiceID  string `json:"voice_id"`
+		Request  struct {
+			Model string `json:"model"`
+		} `json:"request"`
+		Words []struct {
+			Text  string  `json:"text"`
+			Start float64 `json:"start"`
+			End   float64 `json:"end"`
+		} `json:"words"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("decode alignment: %v", err)
+	}
+	if got.Provider != providerMiniMax || got.VoiceID != "v1" || got.Request.Model != "speech-02-hd" {
+		t.Fatalf("unexpected alignment header: %+v", got)
+	}
+	if len(got.Words) != 2 || got.Words[0].Text != "Hello" || got.Words[1].End != 1.2 {
+		t.Fatalf("unexpected words: %+v", got.Words)
+	}
+}
+
+func TestWriteAlignmentIncludesCharacters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.json")
+	timings := subtitles.Timings{Chars: []subtitles.Char{{Text: "H", Start: 0, End: 0.1}, {Text: "i", Start: 0.1, End: 0.2}}}
+	req := ttsRequest{voiceID: "v1", payload: elevenlabs.TTSRequest{Text: "Hi", ModelID: "eleven_v3"}}
+	if err := writeAlignment(path, providerElevenLabs, speakOptions{modelID: "eleven_v3"}, req, timings); err != nil {
+		t.Fatalf("writeAlignment error: %v", err)
+	}
+	raw, _ := os.ReadFile(path)
+	for _, want := range []string{`"characters"`, `"model_id": "eleven_v3"`, `"text": "Hi"`, `"end": 0.2`} {
+		if !strings.Contains(string(raw), want) {
+			t.Fatalf("alignment missing %s:\n%s", want, raw)
+		}
+	}
+}
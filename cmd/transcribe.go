@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+	"github.com/steipete/sag/internal/subtitles"
+	"github.com/steipete/sag/internal/wer"
+
+	"github.com/spf13/cobra"
+)
+
+type transcribeOptions struct {
+	modelID        string
+	language       string
+	diarize        bool
+	numSpeakers    int
+	timestamps     string
+	tagAudioEvents bool
+	format         string
+	outputPath     string
+	verify         string
+	verifyFile     string
+	maxWER         float64
+}
+
+func init() {
+	opts := transcribeOptions{
+		modelID:    "scribe_v1",
+		timestamps: "word",
+	}
+
+	cmd := &cobra.Command{
+		Use:   "transcribe <audio-file>",
+		Short: "Transcribe speech with ElevenLabs speech-to-text",
+		Long:  "Transcribes an audio file ('-' for stdin). With --verify, compares the transcript to the expected text and reports the word error rate on stderr.",
+		Example: "  sag transcribe interview.mp3 --diarize\n" +
+			"  sag transcribe talk.mp3 -o talk.srt\n" +
+			"  sag speak -v Roger -o line.mp3 \"Meet Siobhan at 7\" && sag transcribe line.mp3 --verify \"Meet Siobhan at 7\" --max-wer 0",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := transcriptFormat(opts.format, opts.outputPath)
+			if err != nil {
+				return err
+			}
+			switch opts.timestamps {
+			case "none", "word", "character":
+			default:
+				return errors.New("timestamps must be one of: none, word, character")
+			}
+			if (format == "srt" || format == "vtt") && opts.timestamps == "none" {
+				return fmt.Errorf("%s output needs --timestamps word or character", format)
+			}
+			expected, err := expectedText(opts.verify, opts.verifyFile)
+			if err != nil {
+				return err
+			}
+
+			filename, input, err := readConvertInput(args[0])
+			if err != nil {
+				return err
+			}
+			req := elevenlabs.STTRequest{
+				ModelID:      opts.modelID,
+				LanguageCode: opts.language,
+				Diarize:      opts.diarize,
+				NumSpeakers:  opts.numSpeakers,
+				Timestamps:   opts.timestamps,
+			}
+			if cmd.Flags().Changed("tag-audio-events") {
+				req.TagAudioEvents = &opts.tagAudioEvents
+			}
+
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+			defer cancel()
+			transcript, err := client.Transcribe(ctx, filename, bytes.NewReader(input), req)
+			if err != nil {
+				return err
+			}
+
+			if err := writeTranscript(cmd.OutOrStdout(), opts.outputPath, format, transcript); err != nil {
+				return err
+			}
+			if expected == "" {
+				return nil
+			}
+			result := wer.Compare(expected, transcript.Text)
+			printWERReport(os.Stderr, result)
+			if cmd.Flags().Changed("max-wer") && result.Rate()*100 > opts.maxWER {
+				return fmt.Errorf("word error rate %.1f%% exceeds --max-wer %g", result.Rate()*100, opts.maxWER)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.modelID, "model-id", opts.modelID, "Speech-to-text model")
+	cmd.Flags().StringVar(&opts.language, "language", "", "Language code (ISO 639-1/3; detected when unset)")
+	cmd.Flags().BoolVar(&opts.diarize, "diarize", false, "Label who is speaking")
+	cmd.Flags().IntVar(&opts.numSpeakers, "num-speakers", 0, "Maximum number of speakers (helps diarization; default: detected)")
+	cmd.Flags().StringVar(&opts.timestamps, "timestamps", opts.timestamps, "Timestamp granularity: none|word|character")
+	cmd.Flags().BoolVar(&opts.tagAudioEvents, "tag-audio-events", true, "Tag sounds like (laughter) in the transcript")
+	cmd.Flags().StringVar(&opts.format, "format", "", "Output format: txt|srt|vtt|json (default: from -o extension, else txt)")
+	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "Write the transcript to a file instead of stdout")
+	cmd.Flags().StringVar(&opts.verify, "verify", "", "Expected text; report the word error rate against it")
+	cmd.Flags().StringVar(&opts.verifyFile, "verify-file", "", "Read the expected text from a file")
+	cmd.Flags().Float64Var(&opts.maxWER, "max-wer", 0, "With --verify, fail when the word error rate exceeds this percentage")
+	rootCmd.AddCommand(cmd)
+}
+
+func transcriptFormat(format, outputPath string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(outputPath)), ".")
+		if format != "srt" && format != "vtt" && format != "json" {
+			format = "txt"
+		}
+	}
+	switch format {
+	case "txt", "srt", "vtt", "json":
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported transcript format %q (use txt, srt, vtt, or json)", format)
+	}
+}
+
+func expectedText(verify, verifyFile string) (string, error) {
+	if verify != "" && verifyFile != "" {
+		return "", errors.New("choose only one of --verify or --verify-file")
+	}
+	if verifyFile == "" {
+		return strings.TrimSpace(verify), nil
+	}
+	data, err := os.ReadFile(verifyFile)
+	if err != nil {
+		return "", err
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return "", fmt.Errorf("%s is empty", verifyFile)
+	}
+	return text, nil
+}
+
+func writeTranscript(stdout io.Writer, outputPath, format string, transcript elevenlabs.Transcript) error {
+	var buf bytes.Buffer
+	switch format {
+	case "json":
+		if err := writeJSON(&buf, transcript); err != nil {
+			return err
+		}
+	case "srt", "vtt":
+		cues := subtitles.BuildCues(transcriptWords(transcript), subtitles.DefaultCueOptions)
+		var err error
+		if format == "vtt" {
+			err = subtitles.WriteVTT(&buf, cues)
+		} else {
+			err = subtitles.WriteSRT(&buf, cues)
+		}
+		if err != nil {
+			return err
+		}
+	default:
+		buf.WriteString(transcriptText(transcript))
+		buf.WriteByte('\n')
+	}
+
+	if outputPath == "" {
+		_, err := stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, buf.Bytes(), 0o644)
+}
+
+// transcriptWords returns the spoken words with their timings, skipping spacing and audio events.
+func transcriptWords(t elevenlabs.Transcript) []subtitles.Word {
+	words := make([]subtitles.Word, 0, len(t.Words))
+	for _, w := range t.Words {
+		if w.Type != "" && w.Type != "word" {
+			continue
+		}
+		words = append(words, subtitles.Word{Text: w.Text, Start: w.Start, End: w.End})
+	}
+	return words
+}
+
+// transcriptText is the plain transcript, with one "speaker: text" line per turn when diarized.
+func transcriptText(t elevenlabs.Transcript) string {
+	diarized := false
+	for _, w := range t.Words {
+		if w.SpeakerID != "" {
+			diarized = true
+			break
+		}
+	}
+	if !diarized {
+		return strings.TrimSpace(t.Text)
+	}
+
+	var lines []string
+	var speaker string
+	var cur strings.Builder
+	flush := func() {
+		if text := strings.TrimSpace(cur.String()); text != "" {
+			lines = append(lines, speaker+": "+text)
+		}
+		cur.Reset()
+	}
+	for _, w := range t.Words {
+		if w.SpeakerID != "" && w.SpeakerID != speaker {
+			flush()
+			speaker = w.SpeakerID
+		}
+		cur.WriteString(w.Text)
+	}
+	flush()
+	return strings.Join(lines, "\n")
+}
+
+func printWERReport(w io.Writer, r wer.Result) {
+	_, _ = fmt.Fprintf(w, "WER %.1f%% (%d substitutions, %d deletions, %d insertions; %d expected words)\n",
+		r.Rate()*100, r.Substitutions, r.Deletions, r.Insertions, r.ReferenceWords)
+	for _, e := range r.Edits {
+		switch e.Kind {
+		case wer.Substitution:
+			_, _ = fmt.Fprintf(w, "  expected %q, heard %q\n", e.Expected, e.Got)
+		case wer.Deletion:
+			_, _ = fmt.Fprintf(w, "  missing %q\n", e.Expected)
+		case wer.Insertion:
+			_, _ = fmt.Fprintf(w, "  extra %q\n", e.Got)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+)
+
+const testTranscript = `{"language_code":"eng","text":"Meet Shivon at seven.","words":[
+{"text":"Meet","start":0.0,"end":0.3,"type":"word","speaker_id":"speaker_0"},
+{"text":" ","start":0.3,"end":0.35,"type":"spacing","speaker_id":"speaker_0"},
+{"text":"Shivon","start":0.35,"end":0.8,"type":"word","speaker_id":"speaker_0"},
+{"text":" ","start":0.8,"end":0.85,"type":"spacing","speaker_id":"speaker_0"},
+{"text":"at","start":0.85,"end":1.0,"type":"word","speaker_id":"speaker_1"},
+{"text":" ","start":1.0,"end":1.05,"type":"spacing","speaker_id":"speaker_1"},
+{"text":"seven.","start":1.05,"end":1.5,"type":"word","speaker_id":"speaker_1"}]}`
+
+func newTranscribeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/speech-to-text" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(testTranscript))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTranscribeCommandWritesSRT(t *testing.T) {
+	srv := newTranscribeServer(t)
+	dir := t.TempDir()
+	in := filepath.Join(dir, "line.mp3")
+	if err := os.WriteFile(in, []byte("ID3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "line.srt")
+	if _, err := executeRoot(t, srv.URL, "transcribe", in, "-o", out); err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "1\n00:00:00,000 --> 00:00:01,500\nMeet Shivon at seven.") {
+		t.Fatalf("unexpected srt:\n%s", data)
+	}
+}
+
+func TestTranscribeCommandVerifyFailsOverMaxWER(t *testing.T) {
+	srv := newTranscribeServer(t)
+	in := filepath.Join(t.TempDir(), "line.mp3")
+	if err := os.WriteFile(in, []byte("ID3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := executeRoot(t, srv.URL, "transcribe", in, "-o", "", "--verify", "Meet Siobhan at seven", "--max-wer", "10")
+	if err == nil || !strings.Contains(err.Error(), "25.0%") {
+		t.Fatalf("expected max-wer error, got %v", err)
+	}
+}
+
+func TestTranscriptTextDiarized(t *testing.T) {
+	tr := elevenlabs.Transcript{Text: "a b", Words: []elevenlabs.TranscriptWord{
+		{Text: "Hi", Type: "word", SpeakerID: "speaker_0"},
+		{Text: " ", Type: "spacing", SpeakerID: "speaker_0"},
+		{Text: "Hello", Type: "word", SpeakerID: "speaker_1"},
+	}}
+	if got := transcriptText(tr); got != "speaker_0: Hi\nspeaker_1: Hello" {
+		t.Fatalf("unexpected text %q", got)
+	}
+}
+
+func TestTranscriptFormat(t *testing.T) {
+	cases := map[[2]string]string{
+		{"", ""}:          "txt",
+		{"", "a.vtt"}:     "vtt",
+		{"", "a.md"}:      "txt",
+		{"JSON", "a.srt"}: "json",
+	}
+	for in, want := range cases {
+		if got, err := transcriptFormat(in[0], in[1]); err != nil || got != want {
+			t.Fatalf("transcriptFormat(%q, %q) = %q, %v; want %q", in[0], in[1], got, err, want)
+		}
+	}
+	if _, err := transcriptFormat("docx", ""); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
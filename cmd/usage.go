@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	quotaCacheFileName = "quota.json"
+	quotaCacheTTL      = 10 * time.Minute
+)
+
+// quotaSnapshot is the last known subscription quota, cached so speak can warn without a request every time.
+// Used counts characters sent since the snapshot was fetched.
+type quotaSnapshot struct {
+	Key          string                  `json:"key"`
+	Subscription elevenlabs.Subscription `json:"subscription"`
+	Used         int                     `json:"used"`
+	FetchedAt    time.Time               `json:"fetched_at"`
+}
+
+func (q quotaSnapshot) remaining() int {
+	return max(q.Subscription.Remaining()-q.Used, 0)
+}
+
+func init() {
+	var provider string
+	var days int
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show subscription tier, character quota, and daily usage",
+		Example: "  sag usage\n" +
+			"  sag usage --days 30 --json",
+		Args: cobra.NoArgs,
+		PreRunE: func(*cobra.Command, []string) error {
+			spec, err := lookupProvider(provider)
+			if err != nil {
+				return err
+			}
+			if spec.name != providerElevenLabs {
+				return fmt.Errorf("%s does not report usage through its API; check the provider's console", spec.name)
+			}
+			return ensureAPIKey()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if days < 0 {
+				return errors.New("--days must be >= 0")
+			}
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			sub, err := client.GetSubscription(ctx)
+			if err != nil {
+				return err
+			}
+			saveQuotaSnapshot(quotaSnapshot{Key: apiKeyFingerprint(), Subscription: sub, FetchedAt: time.Now()})
+
+			var daily []elevenlabs.DailyUsage
+			if days > 0 {
+				end := time.Now()
+				start := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location()).AddDate(0, 0, 1-days)
+				if daily, err = client.CharacterUsage(ctx, start, end); err != nil {
+					return err
+				}
+			}
+
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), struct {
+					Subscription elevenlabs.Subscription `json:"subscription"`
+					Remaining    int                     `json:"remaining"`
+					Daily        []elevenlabs.DailyUsage `json:"daily,omitempty"`
+				}{sub, sub.Remaining(), daily})
+			}
+			return printUsage(cmd.OutOrStdout(), sub, daily)
+		},
+	}
+	cmd.Flags().StringVar(&provider, "provider", providerElevenLabs, "Provider to report on (only elevenlabs exposes usage)")
+	cmd.Flags().IntVar(&days, "days", 7, "Show per-day character usage for this many days (0 to skip)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the report as JSON")
+	rootCmd.AddCommand(cmd)
+}
+
+func printUsage(w io.Writer, sub elevenlabs.Subscription, daily []elevenlabs.DailyUsage) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	tier := sub.Tier
+	if sub.Status != "" {
+		tier += " (" + sub.Status + ")"
+	}
+	resets := "unknown"
+	if t := sub.ResetsAt(); !t.IsZero() {
+		resets = t.Local().Format(time.DateTime)
+	}
+	rows := [][2]string{
+		{"tier", tier},
+		{"characters", fmt.Sprintf("%d of %d used, %d remaining", sub.CharacterCount, sub.CharacterLimit, sub.Remaining())},
+		{"resets", resets},
+		{"voice slots", fmt.Sprintf("%d of %d", sub.VoiceSlotsUsed, sub.VoiceLimit)},
+	}
+	for _, r := range rows {
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", r[0], r[1]); err != nil {
+			return err
+		}
+	}
+	if len(daily) > 0 {
+		if _, err := fmt.Fprintln(tw, "\nDATE\tCHARS"); err != nil {
+			return err
+		}
+		for _, d := range daily {
+			if _, err := fmt.Fprintf(tw, "%s\t%d\n", d.Date.Local().Format(time.DateOnly), d.Characters); err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// warnIfOverQuota warns on stderr when chars exceeds the remaining ElevenLabs quota.
+// Quota lookups are best effort: failures never block speaking.
+func warnIfOverQuota(ctx context.Context, chars int) {
+	q, err := loadQuota(ctx, quotaCacheTTL)
+	if err != nil || chars <= q.remaining() {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "warning: this request needs ~%d characters but only %d remain in your ElevenLabs quota%s\n", chars, q.remaining(), quotaResetSuffix(q))
+}
+
+// recordQuotaUse subtracts sent characters from the cached quota so later warnings stay accurate.
+func recordQuotaUse(chars int) {
+	path, err := quotaCachePath()
+	if err != nil {
+		return
+	}
+	q, ok := readQuotaSnapshot(path)
+	if !ok {
+		return
+	}
+	q.Used += chars
+	saveQuotaSnapshot(q)
+}
+
+// printQuotaMetrics fetches the current quota and prints it as a --metrics line.
+func printQuotaMetrics(ctx context.Context) {
+	q, err := loadQuota(ctx, 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "metrics: quota unavailable: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "metrics: quota remaining=%d limit=%d%s\n", q.remaining(), q.Subscription.CharacterLimit, quotaResetSuffix(q))
+}
+
+func quotaResetSuffix(q quotaSnapshot) string {
+	t := q.Subscription.ResetsAt()
+	if t.IsZero() {
+		return ""
+	}
+	return " (resets " + t.Local().Format(time.DateOnly) + ")"
+}
+
+// loadQuota returns the cached quota if it is younger than maxAge, otherwise fetches a fresh one.
+func loadQuota(ctx context.Context, maxAge time.Duration) (quotaSnapshot, error) {
+	path, err := quotaCachePath()
+	if err != nil {
+		return quotaSnapshot{}, err
+	}
+	if q, ok := readQuotaSnapshot(path); ok && time.Since(q.FetchedAt) < maxAge {
+		return q, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	sub, err := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL).GetSubscription(ctx)
+	if err != nil {
+		return quotaSnapshot{}, err
+	}
+	q := quotaSnapshot{Key: apiKeyFingerprint(), Subscription: sub, FetchedAt: time.Now()}
+	saveQuotaSnapshot(q)
+	return q, nil
+}
+
+func quotaCachePath() (string, error) {
+	path, err := voiceCachePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), quotaCacheFileName), nil
+}
+
+// readQuotaSnapshot returns the cached snapshot if it belongs to the current API key.
+func readQuotaSnapshot(path string) (quotaSnapshot, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return quotaSnapshot{}, false
+	}
+	var q quotaSnapshot
+	if err := json.Unmarshal(data, &q); err != nil || q.Key != apiKeyFingerprint() {
+		return quotaSnapshot{}, false
+	}
+	return q, true
+}
+
+func saveQuotaSnapshot(q quotaSnapshot) {
+	path, err := quotaCachePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// apiKeyFingerprint identifies the account a cached quota belongs to without storing the key.
+func apiKeyFingerprint() string {
+	sum := sha256.Sum256([]byte(cfg.BaseURL + "\x00" + cfg.APIKey))
+	return hex.EncodeToString(sum[:8])
+}
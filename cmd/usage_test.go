@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testSubscription = `{"tier":"creator","status":"active","character_count":40000,"character_limit":100000,"next_character_count_reset_unix":1793000000,"voice_slots_used":3,"voice_limit":30}`
+
+func TestUsageCommandReport(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/user/subscription":
+			_, _ = w.Write([]byte(testSubscription))
+		case "/v1/usage/character-stats":
+			_, _ = w.Write([]byte(`{"time":[1760000000000],"usage":{"All":[1234]}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	out, err := executeRoot(t, srv.URL, "usage", "--days", "1")
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	for _, want := range []string{"creator (active)", "40000 of 100000 used, 60000 remaining", "3 of 30", "1234"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestQuotaCacheTracksUse(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(testSubscription))
+	}))
+	defer srv.Close()
+	cfg.APIKey = "key"
+	cfg.BaseURL = srv.URL
+
+	q, err := loadQuota(t.Context(), quotaCacheTTL)
+	if err != nil || q.remaining() != 60000 {
+		t.Fatalf("unexpected quota %+v, %v", q, err)
+	}
+	recordQuotaUse(59000)
+	q, err = loadQuota(t.Context(), quotaCacheTTL)
+	if err != nil || q.remaining() != 1000 || requests != 1 {
+		t.Fatalf("expected cached quota with 1000 remaining after 1 request, got %+v, %v, %d requests", q, err, requests)
+	}
+
+	cfg.APIKey = "other"
+	if _, err := loadQuota(t.Context(), quotaCacheTTL); err != nil || requests != 2 {
+		t.Fatalf("a different API key must not reuse the cache (%d requests, %v)", requests, err)
+	}
+}
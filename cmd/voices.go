@@ -11,7 +11,6 @@ import (
 	"text/tabwriter"
 	"time"
 
-	"github.com/steipete/sag/internal/audio"
 	"github.com/steipete/sag/internal/elevenlabs"
 
 	"github.com/spf13/cobra"
@@ -52,6 +51,9 @@ func init() {
 			if opts.try && opts.search == "" && opts.query == "" && !hasLabelFilters && !cmd.Flags().Changed("limit") {
 				return errors.New("--try requires --search, --query, --label, or --limit to avoid playing all voices")
 			}
+			if err := checkPlayer(opts.try); err != nil {
+				return err
+			}
 
 			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
@@ -166,6 +168,7 @@ func init() {
 	cmd.Flags().StringArrayVar(&opts.labels, "label", nil, "Filter by voice label (key=value); repeatable")
 	cmd.Flags().IntVar(&opts.limit, "limit", opts.limit, "Maximum rows to display (0 = all)")
 	cmd.Flags().BoolVar(&opts.try, "try", false, "Play preview audio for listed voices (requires --search, --query, --label, or --limit)")
+	cmd.AddCommand(newVoicesCloneCmd(), newVoicesAddCmd(), newVoicesEditCmd(), newVoicesDeleteCmd(), newVoicesDesignCmd())
 	rootCmd.AddCommand(cmd)
 }
 
@@ -195,7 +198,11 @@ func playVoicePreviewImpl(ctx context.Context, client *elevenlabs.Client, voice
 	if previewURL == "" {
 		return errors.New("preview URL unavailable")
 	}
+	return playPreviewURL(ctx, previewURL)
+}
 
+// playPreviewURL downloads preview audio and plays it.
+func playPreviewURL(ctx context.Context, previewURL string) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, previewURL, nil)
 	if err != nil {
 		return err
@@ -213,5 +220,5 @@ func playVoicePreviewImpl(ctx context.Context, client *elevenlabs.Client, voice
 		return fmt.Errorf("preview download failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
 	}
 
-	return audio.StreamToSpeakers(ctx, resp.Body)
+	return playAudio(ctx, resp.Body, "")
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
@@ -80,6 +81,24 @@ func saveVoiceCache(path string, cache *voiceCache) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
+// updateVoiceCache applies fn to the on-disk cache. Failures only warn: the cache is an optimization.
+func updateVoiceCache(fn func(*voiceCache)) {
+	path, err := voiceCachePath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: voice cache disabled: %v\n", err)
+		return
+	}
+	cache, err := loadVoiceCache(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to load voice cache: %v\n", err)
+		return
+	}
+	fn(cache)
+	if err := saveVoiceCache(path, cache); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to save voice cache: %v\n", err)
+	}
+}
+
 func hydrateVoices(ctx context.Context, client *elevenlabs.Client, voices []elevenlabs.Voice, cache *voiceCache, ttl time.Duration) ([]elevenlabs.Voice, int) {
 	if ttl <= 0 {
 		ttl = voiceCacheTTL
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/minimax"
+
+	"github.com/spf13/cobra"
+)
+
+// MiniMax accepts 10 s – 5 min of mp3/m4a/wav audio, up to 20 MB.
+const maxCloneSampleBytes = 20 << 20
+
+var miniMaxVoiceIDPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{6,254}[A-Za-z0-9]$`)
+
+type voiceCloneOptions struct {
+	provider        string
+	file            string
+	voiceID         string
+	text            string
+	modelID         string
+	noiseReduction  bool
+	normalizeVolume bool
+	play            bool
+}
+
+func newVoicesCloneCmd() *cobra.Command {
+	opts := voiceCloneOptions{
+		provider: providerMiniMax,
+		modelID:  "speech-02-hd",
+		play:     true,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone",
+		Short: "Clone a voice from a local audio sample (MiniMax)",
+		Example: "  sag voices clone --provider minimax --file sample.mp3 --voice-id narrator_01\n" +
+			"  sag voices clone --file sample.wav --voice-id narrator_01 --noise-reduction --text \"Hello from my new voice\"",
+		Args: cobra.NoArgs,
+		PreRunE: func(*cobra.Command, []string) error {
+			if !strings.EqualFold(strings.TrimSpace(opts.provider), providerMiniMax) {
+				return fmt.Errorf("voices clone supports --provider minimax only (got %q); use `sag voices add` for ElevenLabs", opts.provider)
+			}
+			if err := validateCloneInput(opts); err != nil {
+				return err
+			}
+			return ensureMiniMaxAPIKey()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := checkPlayer(opts.play && strings.TrimSpace(opts.text) != ""); err != nil {
+				return err
+			}
+			client := minimax.NewClient(cfg.APIKey, minimaxBaseURL())
+			ctx, cancel := context.WithTimeout(cmd.Context(), 3*time.Minute)
+			defer cancel()
+
+			result, err := cloneMiniMaxVoice(ctx, client, opts)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "cloned voice %s\n", opts.voiceID)
+			fmt.Fprintln(os.Stderr, "note: MiniMax keeps cloned voices only after their first use in speech synthesis (within 7 days)")
+			if result.InputSensitive {
+				fmt.Fprintln(os.Stderr, "warning: MiniMax flagged the preview text as sensitive")
+			}
+			if result.DemoAudioURL == "" {
+				if strings.TrimSpace(opts.text) != "" {
+					fmt.Fprintln(os.Stderr, "warning: MiniMax returned no preview audio")
+				}
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "preview: %s\n", result.DemoAudioURL)
+			if opts.play {
+				return playPreviewURL(cmd.Context(), result.DemoAudioURL)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.provider, "provider", opts.provider, "Voice provider (minimax)")
+	cmd.Flags().StringVar(&opts.file, "file", "", "Audio sample to clone (mp3, m4a, or wav; 10 s to 5 min, at most 20 MB)")
+	cmd.Flags().StringVar(&opts.voiceID, "voice-id", "", "ID for the new voice (8-256 chars: letters, digits, - and _; starts with a letter)")
+	cmd.Flags().StringVar(&opts.text, "text", "", "Preview text to synthesize with the new voice")
+	cmd.Flags().StringVar(&opts.modelID, "model-id", opts.modelID, "Model used for the preview")
+	cmd.Flags().BoolVar(&opts.noiseReduction, "noise-reduction", false, "Reduce background noise in the sample")
+	cmd.Flags().BoolVar(&opts.normalizeVolume, "normalize-volume", false, "Normalize sample loudness")
+	cmd.Flags().BoolVar(&opts.play, "play", opts.play, "Play the preview through speakers (with --text)")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("voice-id")
+	return cmd
+}
+
+func validateCloneInput(opts voiceCloneOptions) error {
+	if !miniMaxVoiceIDPattern.MatchString(opts.voiceID) {
+		return fmt.Errorf("invalid --voice-id %q: use 8-256 letters, digits, - or _, starting with a letter and not ending with - or _", opts.voiceID)
+	}
+	switch strings.ToLower(filepath.Ext(opts.file)) {
+	case ".mp3", ".m4a", ".wav":
+	default:
+		return fmt.Errorf("unsupported sample %q: MiniMax accepts mp3, m4a, or wav", opts.file)
+	}
+	info, err := os.Stat(opts.file)
+	if err != nil {
+		return err
+	}
+	if info.Size() > maxCloneSampleBytes {
+		return fmt.Errorf("sample %s is %d MB; MiniMax accepts at most 20 MB", opts.file, info.Size()>>20)
+	}
+	return nil
+}
+
+// cloneMiniMaxVoice uploads the sample and creates the voice.
+func cloneMiniMaxVoice(ctx context.Context, client *minimax.Client, opts voiceCloneOptions) (minimax.VoiceCloneResult, error) {
+	f, err := os.Open(opts.file)
+	if err != nil {
+		return minimax.VoiceCloneResult{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	fileID, err := client.UploadFile(ctx, "voice_clone", filepath.Base(opts.file), f)
+	if err != nil {
+		return minimax.VoiceCloneResult{}, err
+	}
+	req := minimax.VoiceCloneRequest{
+		FileID:              fileID,
+		VoiceID:             opts.voiceID,
+		NoiseReduction:      opts.noiseReduction,
+		VolumeNormalization: opts.normalizeVolume,
+	}
+	if text := strings.TrimSpace(opts.text); text != "" {
+		req.PreviewText = text
+		req.PreviewModel = opts.modelID
+	}
+	return client.CloneVoice(ctx, req)
+}
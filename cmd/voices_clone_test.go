@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/sag/internal/minimax"
+)
+
+func TestCloneMiniMaxVoiceUploadsThenClones(t *testing.T) {
+	sample := filepath.Join(t.TempDir(), "sample.mp3")
+	if err := os.WriteFile(sample, []byte("ID3sample"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var clone map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/files/upload":
+			if r.FormValue("purpose") != "voice_clone" {
+				t.Fatalf("unexpected purpose %q", r.FormValue("purpose"))
+			}
+			f, hdr, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("form file: %v", err)
+			}
+			data, _ := io.ReadAll(f)
+			if hdr.Filename != "sample.mp3" || string(data) != "ID3sample" {
+				t.Fatalf("unexpected upload %s %q", hdr.Filename, data)
+			}
+			_, _ = w.Write([]byte(`{"file":{"file_id":123},"base_resp":{"status_code":0}}`))
+		case "/v1/voice_clone":
+			if err := json.NewDecoder(r.Body).Decode(&clone); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"demo_audio":"https://example.com/demo.mp3","base_resp":{"status_code":0}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	opts := voiceCloneOptions{file: sample, voiceID: "narrator_01", text: "Hello there", modelID: "speech-02-hd", noiseReduction: true}
+	result, err := cloneMiniMaxVoice(t.Context(), minimax.NewClient("key", srv.URL), opts)
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if result.DemoAudioURL != "https://example.com/demo.mp3" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if clone["file_id"] != float64(123) || clone["voice_id"] != "narrator_01" || clone["need_noise_reduction"] != true ||
+		clone["text"] != "Hello there" || clone["model"] != "speech-02-hd" {
+		t.Fatalf("unexpected clone payload %v", clone)
+	}
+	if _, ok := clone["need_volume_normalization"]; ok {
+		t.Fatalf("volume normalization should be omitted: %v", clone)
+	}
+}
+
+func TestValidateCloneInput(t *testing.T) {
+	dir := t.TempDir()
+	sample := filepath.Join(dir, "sample.wav")
+	if err := os.WriteFile(sample, []byte("RIFF"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		opts    voiceCloneOptions
+		wantErr string
+	}{
+		{"ok", voiceCloneOptions{file: sample, voiceID: "Narrator-01"}, ""},
+		{"short id", voiceCloneOptions{file: sample, voiceID: "abc"}, "invalid --voice-id"},
+		{"leading digit", voiceCloneOptions{file: sample, voiceID: "1narrator"}, "invalid --voice-id"},
+		{"trailing underscore", voiceCloneOptions{file: sample, voiceID: "narrator_"}, "invalid --voice-id"},
+		{"bad format", voiceCloneOptions{file: filepath.Join(dir, "sample.ogg"), voiceID: "narrator_01"}, "unsupported sample"},
+		{"missing file", voiceCloneOptions{file: filepath.Join(dir, "missing.mp3"), voiceID: "narrator_01"}, "no such file"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCloneInput(tc.opts)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %q error, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
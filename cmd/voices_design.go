@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+	"github.com/steipete/sag/internal/minimax"
+
+	"github.com/spf13/cobra"
+)
+
+var playDesignPreview = func(ctx context.Context, data []byte) error {
+	return playAudio(ctx, bytes.NewReader(data), "")
+}
+
+// designCandidate is one generated voice awaiting a decision.
+type designCandidate struct {
+	id    string
+	audio []byte
+}
+
+// voiceDesigner generates candidate voices from a description and keeps the chosen one.
+type voiceDesigner interface {
+	design(ctx context.Context, description, text string) ([]designCandidate, error)
+	save(ctx context.Context, c designCandidate, name string) (string, error)
+}
+
+type voiceDesignOptions struct {
+	provider   string
+	text       string
+	count      int
+	name       string
+	labels     []string
+	pick       int
+	play       bool
+	previewDir string
+}
+
+func newVoicesDesignCmd() *cobra.Command {
+	opts := voiceDesignOptions{
+		provider: providerElevenLabs,
+		count:    3,
+		play:     true,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "design <description>",
+		Short: "Generate new voices from a text description and save one",
+		Example: "  sag voices design \"gravelly old sea captain, slow, warm\"\n" +
+			"  sag voices design --provider minimax --count 2 \"bright young radio host\"\n" +
+			"  sag voices design --play=false --preview-dir previews --pick 2 --name Captain \"gravelly old sea captain\"",
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(*cobra.Command, []string) error {
+			if opts.count < 1 {
+				return errors.New("--count must be at least 1")
+			}
+			return ensureAPIKeyForProvider(opts.provider)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkPlayer(opts.play); err != nil {
+				return err
+			}
+			description := strings.TrimSpace(strings.Join(args, " "))
+			labels, err := parseVoiceLabels(opts.labels)
+			if err != nil {
+				return err
+			}
+			designer, err := newVoiceDesigner(opts.provider, description, labels)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+			defer cancel()
+			candidates, err := designCandidates(ctx, designer, description, opts.text, opts.count)
+			if err != nil {
+				return err
+			}
+			if err := presentCandidates(ctx, opts, candidates); err != nil {
+				return err
+			}
+
+			choice, err := pickCandidate(opts.pick, len(candidates))
+			if err != nil {
+				return err
+			}
+			if choice == 0 {
+				fmt.Fprintln(os.Stderr, "no voice saved")
+				return nil
+			}
+			name := strings.TrimSpace(opts.name)
+			if name == "" {
+				name = defaultDesignName(description)
+			}
+			voiceID, err := designer.save(ctx, candidates[choice-1], name)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "saved voice %s\n", name)
+			fmt.Fprintln(cmd.OutOrStdout(), voiceID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.provider, "provider", opts.provider, "Voice provider (elevenlabs|minimax)")
+	cmd.Flags().StringVar(&opts.text, "text", "", "Preview text (ElevenLabs: 100-1000 chars; generated when empty)")
+	cmd.Flags().IntVar(&opts.count, "count", opts.count, "Number of candidates to generate")
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the saved ElevenLabs voice (default: from the description; MiniMax keeps the generated ID)")
+	cmd.Flags().StringArrayVar(&opts.labels, "label", nil, "ElevenLabs label for the saved voice (key=value); repeatable")
+	cmd.Flags().IntVar(&opts.pick, "pick", 0, "Save candidate N without prompting")
+	cmd.Flags().BoolVar(&opts.play, "play", opts.play, "Play each candidate through speakers")
+	cmd.Flags().StringVar(&opts.previewDir, "preview-dir", "", "Also write candidate previews to this directory")
+	return cmd
+}
+
+func newVoiceDesigner(provider, description string, labels map[string]string) (voiceDesigner, error) {
+	spec, err := lookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	switch spec.name {
+	case providerElevenLabs:
+		if n := len([]rune(description)); n < 20 || n > 1000 {
+			return nil, fmt.Errorf("ElevenLabs voice descriptions must be 20-1000 characters (got %d)", n)
+		}
+		if len(labels) == 0 {
+			labels = nil
+		}
+		return &elevenLabsDesigner{client: elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL), description: description, labels: labels}, nil
+	case providerMiniMax:
+		return &miniMaxDesigner{client: minimax.NewClient(cfg.APIKey, minimaxBaseURL())}, nil
+	default:
+		return nil, fmt.Errorf("voice design is not supported by %s (use elevenlabs or minimax)", spec.name)
+	}
+}
+
+// designCandidates requests batches until count candidates exist.
+func designCandidates(ctx context.Context, designer voiceDesigner, description, text string, count int) ([]designCandidate, error) {
+	var candidates []designCandidate
+	for len(candidates) < count {
+		batch, err := designer.design(ctx, description, text)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		candidates = append(candidates, batch...)
+	}
+	if len(candidates) > count {
+		candidates = candidates[:count]
+	}
+	return candidates, nil
+}
+
+func presentCandidates(ctx context.Context, opts voiceDesignOptions, candidates []designCandidate) error {
+	if opts.previewDir != "" {
+		if err := os.MkdirAll(opts.previewDir, 0o755); err != nil {
+			return err
+		}
+	}
+	for i, c := range candidates {
+		fmt.Fprintf(os.Stderr, "candidate %d/%d (%s)\n", i+1, len(candidates), c.id)
+		if opts.previewDir != "" {
+			path := filepath.Join(opts.previewDir, fmt.Sprintf("candidate-%d.mp3", i+1))
+			if err := os.WriteFile(path, c.audio, 0o644); err != nil {
+				return err
+			}
+		}
+		if opts.play {
+			if err := playDesignPreview(ctx, c.audio); err != nil {
+				fmt.Fprintf(os.Stderr, "preview failed for candidate %d: %v\n", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// pickCandidate returns the 1-based candidate to save, or 0 to save none.
+func pickCandidate(pick, count int) (int, error) {
+	if pick != 0 {
+		if pick < 0 || pick > count {
+			return 0, fmt.Errorf("--pick must be between 1 and %d", count)
+		}
+		return pick, nil
+	}
+	if promptInput == os.Stdin && !isStdinTTY() {
+		fmt.Fprintln(os.Stderr, "stdin is not a terminal; pass --pick N to save a candidate")
+		return 0, nil
+	}
+	for {
+		answer, err := promptLine(fmt.Sprintf("Save which voice? [1-%d, Enter to skip] ", count))
+		if err != nil || answer == "" {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= count {
+			return n, nil
+		}
+		fmt.Fprintf(os.Stderr, "enter a number between 1 and %d\n", count)
+	}
+}
+
+func defaultDesignName(description string) string {
+	name := strings.TrimSpace(strings.SplitN(description, ",", 2)[0])
+	if r := []rune(name); len(r) > 40 {
+		name = strings.TrimSpace(string(r[:40]))
+	}
+	return name
+}
+
+type elevenLabsDesigner struct {
+	client      *elevenlabs.Client
+	description string
+	labels      map[string]string
+}
+
+func (d *elevenLabsDesigner) design(ctx context.Context, description, text string) ([]designCandidate, error) {
+	previews, _, err := d.client.DesignVoice(ctx, elevenlabs.VoiceDesignRequest{Description: description, Text: text})
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]designCandidate, 0, len(previews))
+	for _, p := range previews {
+		candidates = append(candidates, designCandidate{id: p.GeneratedVoiceID, audio: p.Audio})
+	}
+	return candidates, nil
+}
+
+func (d *elevenLabsDesigner) save(ctx context.Context, c designCandidate, name string) (string, error) {
+	voice, err := d.client.CreateVoiceFromPreview(ctx, name, d.description, c.id, d.labels)
+	if err != nil {
+		return "", err
+	}
+	warmVoiceCache(ctx, d.client, voice)
+	return voice.VoiceID, nil
+}
+
+// miniMaxSaveText is synthesized once to keep a designed voice; MiniMax drops voices unused for 7 days.
+const miniMaxSaveText = "Hello."
+
+type miniMaxDesigner struct {
+	client *minimax.Client
+}
+
+func (d *miniMaxDesigner) design(ctx context.Context, description, text string) ([]designCandidate, error) {
+	if text == "" {
+		text = "Hello! This is a preview of a newly designed voice. How does it sound to you?"
+	}
+	voiceID, data, err := d.client.DesignVoice(ctx, description, text)
+	if err != nil {
+		return nil, err
+	}
+	return []designCandidate{{id: voiceID, audio: data}}, nil
+}
+
+func (d *miniMaxDesigner) save(ctx context.Context, c designCandidate, _ string) (string, error) {
+	spec, err := lookupProvider(providerMiniMax)
+	if err != nil {
+		return "", err
+	}
+	if _, err := d.client.ConvertTTS(ctx, c.id, minimax.TTSRequest{Model: spec.defaultModel, Text: miniMaxSaveText}); err != nil {
+		return "", fmt.Errorf("keep designed voice: %w", err)
+	}
+	return c.id, nil
+}
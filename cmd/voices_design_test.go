@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVoicesDesignPlaysCandidatesAndSavesPick(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/text-to-voice/design":
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["voice_description"] != "gravelly old sea captain, slow, warm" || req["auto_generate_text"] != true {
+				t.Fatalf("unexpected design request %v", req)
+			}
+			audio := base64.StdEncoding.EncodeToString([]byte("ID3"))
+			_, _ = w.Write([]byte(`{"previews":[` +
+				`{"audio_base_64":"` + audio + `","generated_voice_id":"g1"},` +
+				`{"audio_base_64":"` + audio + `","generated_voice_id":"g2"},` +
+				`{"audio_base_64":"` + audio + `","generated_voice_id":"g3"}],"text":"Ahoy"}`))
+		case "/v1/text-to-voice":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"voice_id":"captain1","name":"gravelly old sea captain"}`))
+		case "/v1/voices/captain1":
+			_, _ = w.Write([]byte(`{"voice_id":"captain1","name":"gravelly old sea captain","category":"generated"}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	var played int
+	origPlay := playDesignPreview
+	playDesignPreview = func(context.Context, []byte) error { played++; return nil }
+	promptInput = strings.NewReader("2\n")
+	defer func() {
+		playDesignPreview = origPlay
+		promptInput = os.Stdin
+	}()
+
+	out, err := executeRoot(t, srv.URL, "voices", "design", "gravelly old sea captain, slow, warm")
+	if err != nil {
+		t.Fatalf("voices design: %v", err)
+	}
+	if played != 3 {
+		t.Fatalf("expected 3 previews played, got %d", played)
+	}
+	if created["generated_voice_id"] != "g2" || created["voice_name"] != "gravelly old sea captain" {
+		t.Fatalf("unexpected create request %v", created)
+	}
+	if strings.TrimSpace(out) != "captain1" {
+		t.Fatalf("expected saved voice ID, got %q", out)
+	}
+	if _, ok := loadTestVoiceCache(t).Voices["captain1"]; !ok {
+		t.Fatal("saved voice not cached")
+	}
+}
+
+type fakeDesigner struct{ calls int }
+
+func (f *fakeDesigner) design(context.Context, string, string) ([]designCandidate, error) {
+	f.calls++
+	return []designCandidate{{id: "v"}}, nil
+}
+
+func (f *fakeDesigner) save(_ context.Context, c designCandidate, _ string) (string, error) {
+	return c.id, nil
+}
+
+func TestDesignCandidatesRequestsBatchesUntilCount(t *testing.T) {
+	f := &fakeDesigner{}
+	candidates, err := designCandidates(context.Background(), f, "desc", "", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 3 || f.calls != 3 {
+		t.Fatalf("got %d candidates after %d calls", len(candidates), f.calls)
+	}
+}
+
+func TestPickCandidateValidatesRange(t *testing.T) {
+	if _, err := pickCandidate(4, 3); err == nil {
+		t.Fatal("expected error for out-of-range --pick")
+	}
+	if n, err := pickCandidate(3, 3); err != nil || n != 3 {
+		t.Fatalf("pickCandidate = %d, %v", n, err)
+	}
+}
+
+func TestNewVoiceDesignerRejectsShortElevenLabsDescription(t *testing.T) {
+	if _, err := newVoiceDesigner(providerElevenLabs, "pirate", nil); err == nil {
+		t.Fatal("expected error for short description")
+	}
+	if _, err := newVoiceDesigner(providerOpenAI, "a perfectly long description", nil); err == nil {
+		t.Fatal("expected error for provider without voice design")
+	}
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/sag/internal/elevenlabs"
+
+	"github.com/spf13/cobra"
+)
+
+// ElevenLabs instant voice cloning accepts up to 25 samples per voice.
+const maxVoiceSamples = 25
+
+// promptInput is where interactive answers are read from (replaced in tests).
+var promptInput io.Reader = os.Stdin
+
+type voiceEditOptions struct {
+	name                  string
+	description           string
+	labels                []string
+	files                 []string
+	removeBackgroundNoise bool
+}
+
+func newVoicesAddCmd() *cobra.Command {
+	var opts voiceEditOptions
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Create an ElevenLabs instant voice clone from audio samples",
+		Example: "  sag voices add --name Captain --file a.mp3 --file b.wav --label accent=british\n" +
+			"  sag speak -v Captain \"Ahoy\"",
+		Args:    cobra.NoArgs,
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			labels, err := parseVoiceLabels(opts.labels)
+			if err != nil {
+				return err
+			}
+			samples, closeSamples, err := openVoiceSamples(opts.files)
+			if err != nil {
+				return err
+			}
+			defer closeSamples()
+
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 3*time.Minute)
+			defer cancel()
+			params := elevenlabs.VoiceParams{
+				Name:                  strings.TrimSpace(opts.name),
+				Description:           opts.description,
+				Samples:               samples,
+				RemoveBackgroundNoise: opts.removeBackgroundNoise,
+			}
+			if len(labels) > 0 {
+				params.Labels = labels
+			}
+			voiceID, err := client.AddVoice(ctx, params)
+			if err != nil {
+				return err
+			}
+			warmVoiceCache(ctx, client, elevenlabs.Voice{
+				VoiceID:     voiceID,
+				Name:        params.Name,
+				Category:    "cloned",
+				Description: params.Description,
+				Labels:      params.Labels,
+			})
+			fmt.Fprintf(os.Stderr, "added voice %s\n", params.Name)
+			fmt.Fprintln(cmd.OutOrStdout(), voiceID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name of the new voice")
+	cmd.Flags().StringVar(&opts.description, "description", "", "Voice description")
+	cmd.Flags().StringArrayVar(&opts.labels, "label", nil, "Voice label (key=value); repeatable")
+	cmd.Flags().StringArrayVar(&opts.files, "file", nil, "Audio sample (mp3, wav, ...); repeatable, up to 25")
+	cmd.Flags().BoolVar(&opts.removeBackgroundNoise, "remove-background-noise", false, "Remove background noise from the samples")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func newVoicesEditCmd() *cobra.Command {
+	var opts voiceEditOptions
+	cmd := &cobra.Command{
+		Use:   "edit <voice>",
+		Short: "Rename, relabel, or add samples to an ElevenLabs voice",
+		Example: "  sag voices edit Captain --name \"Old Captain\"\n" +
+			"  sag voices edit Captain --label age=old --label accent=   # set age, remove accent\n" +
+			"  sag voices edit Captain --file more.mp3",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("description") && len(opts.labels) == 0 && len(opts.files) == 0 {
+				return errors.New("nothing to change; pass --name, --description, --label, or --file")
+			}
+			labels, err := parseVoiceLabels(opts.labels)
+			if err != nil {
+				return err
+			}
+			samples, closeSamples, err := openVoiceSamples(opts.files)
+			if err != nil {
+				return err
+			}
+			defer closeSamples()
+
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 3*time.Minute)
+			defer cancel()
+			voiceID, err := resolveExactVoice(ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			current, err := client.GetVoice(ctx, voiceID)
+			if err != nil {
+				return err
+			}
+
+			// The edit endpoint replaces every field, so start from the current voice.
+			params := elevenlabs.VoiceParams{
+				Name:                  current.Name,
+				Description:           current.Description,
+				Labels:                mergeVoiceLabels(current.Labels, labels),
+				Samples:               samples,
+				RemoveBackgroundNoise: opts.removeBackgroundNoise,
+			}
+			if cmd.Flags().Changed("name") {
+				if params.Name = strings.TrimSpace(opts.name); params.Name == "" {
+					return errors.New("--name cannot be empty")
+				}
+			}
+			if cmd.Flags().Changed("description") {
+				params.Description = opts.description
+			}
+			if err := client.EditVoice(ctx, voiceID, params); err != nil {
+				return err
+			}
+			updated := current
+			updated.Name, updated.Description, updated.Labels = params.Name, params.Description, params.Labels
+			warmVoiceCache(ctx, client, updated)
+			fmt.Fprintf(cmd.OutOrStdout(), "updated voice %s (%s)\n", params.Name, voiceID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&opts.name, "name", "", "New voice name")
+	cmd.Flags().StringVar(&opts.description, "description", "", "New voice description")
+	cmd.Flags().StringArrayVar(&opts.labels, "label", nil, "Set a label (key=value) or remove it (key=); repeatable")
+	cmd.Flags().StringArrayVar(&opts.files, "file", nil, "Additional audio sample; repeatable")
+	cmd.Flags().BoolVar(&opts.removeBackgroundNoise, "remove-background-noise", false, "Remove background noise from added samples")
+	return cmd
+}
+
+// resolveExactVoice maps a voice ID or an exact, unique name (case-insensitive) to an ID.
+// Unlike resolveVoice it never falls back to a partial name match, since edit and delete change the voice.
+func resolveExactVoice(ctx context.Context, client *elevenlabs.Client, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", errors.New("voice is required")
+	}
+	if looksLikeVoiceID(input) && containsDigit(input) {
+		return input, nil
+	}
+	voices, err := client.ListVoices(ctx)
+	if err != nil {
+		return "", err
+	}
+	var matchID string
+	var matches, similar []string
+	for _, v := range voices {
+		switch {
+		case v.VoiceID == input:
+			return v.VoiceID, nil
+		case strings.EqualFold(v.Name, input):
+			matchID = v.VoiceID
+			matches = append(matches, fmt.Sprintf("%s (%s)", v.Name, v.VoiceID))
+		case strings.Contains(strings.ToLower(v.Name), strings.ToLower(input)):
+			similar = append(similar, fmt.Sprintf("%s (%s)", v.Name, v.VoiceID))
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		return matchID, nil
+	case len(matches) > 1:
+		return "", fmt.Errorf("voice name %q is ambiguous: %s; pass the voice ID", input, strings.Join(matches, ", "))
+	case len(similar) > 0:
+		return "", fmt.Errorf("no voice named exactly %q (similar: %s); pass the full name or voice ID", input, strings.Join(similar, ", "))
+	default:
+		return "", fmt.Errorf("voice %q not found", input)
+	}
+}
+
+func newVoicesDeleteCmd() *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:     "delete <voice>",
+		Short:   "Delete an ElevenLabs voice (asks for confirmation)",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: func(*cobra.Command, []string) error { return ensureAPIKey() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := elevenlabs.NewClient(cfg.APIKey, cfg.BaseURL)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+			voiceID, err := resolveExactVoice(ctx, client, args[0])
+			if err != nil {
+				return err
+			}
+			voice, err := client.GetVoice(ctx, voiceID)
+			if err != nil {
+				return err
+			}
+			if !yes {
+				ok, err := confirm(fmt.Sprintf("Delete voice %s (%s)? This cannot be undone. [y/N] ", voice.Name, voiceID))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return errors.New("aborted")
+				}
+			}
+			if err := client.DeleteVoice(ctx, voiceID); err != nil {
+				return err
+			}
+			updateVoiceCache(func(cache *voiceCache) { delete(cache.Voices, voiceID) })
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted voice %s (%s)\n", voice.Name, voiceID)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	return cmd
+}
+
+// confirm asks a yes/no question on stderr. Non-interactive input must use --yes.
+func confirm(prompt string) (bool, error) {
+	if promptInput == os.Stdin && !isStdinTTY() {
+		return false, errors.New("stdin is not a terminal; pass --yes to confirm")
+	}
+	answer, err := promptLine(prompt)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// promptLine prints prompt on stderr and reads one trimmed line of input.
+func promptLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := bufio.NewReader(promptInput).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// parseVoiceLabels parses key=value labels. An empty value marks the key for removal.
+func parseVoiceLabels(raw []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, item := range raw {
+		key, value, ok := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("label %q must be key=value", item)
+		}
+		labels[key] = strings.TrimSpace(value)
+	}
+	return labels, nil
+}
+
+func mergeVoiceLabels(current, changes map[string]string) map[string]string {
+	merged := make(map[string]string, len(current)+len(changes))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		if v == "" {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+func openVoiceSamples(paths []string) ([]elevenlabs.VoiceSample, func(), error) {
+	if len(paths) > maxVoiceSamples {
+		return nil, nil, fmt.Errorf("%d samples given; ElevenLabs accepts at most %d", len(paths), maxVoiceSamples)
+	}
+	var files []*os.File
+	closeAll := func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}
+	samples := make([]elevenlabs.VoiceSample, 0, len(paths))
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		files = append(files, f)
+		samples = append(samples, elevenlabs.VoiceSample{Filename: filepath.Base(p), Data: f})
+	}
+	return samples, closeAll, nil
+}
+
+// warmVoiceCache stores fresh metadata for a created or edited voice so --query/--label see it immediately.
+func warmVoiceCache(ctx context.Context, client *elevenlabs.Client, fallback elevenlabs.Voice) {
+	voice := fallback
+	if details, err := client.GetVoice(ctx, fallback.VoiceID); err == nil {
+		voice = mergeVoice(fallback, details)
+	}
+	updateVoiceCache(func(cache *voiceCache) {
+		cache.Voices[voice.VoiceID] = cachedVoice{Voice: voice, UpdatedAt: time.Now()}
+	})
+}
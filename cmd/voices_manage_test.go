@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testVoiceID = "21m00Tcm4TlvDq8ikWAM"
+
+func executeRoot(t *testing.T, srvURL string, args ...string) (string, error) {
+	t.Helper()
+	cfg.APIKey = "key"
+	cfg.BaseURL = srvURL
+	buf := &bytes.Buffer{}
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(args)
+	defer func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	}()
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+func loadTestVoiceCache(t *testing.T) *voiceCache {
+	t.Helper()
+	path, err := voiceCachePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache, err := loadVoiceCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cache
+}
+
+func TestVoicesAddUploadsSamplesAndWarmsCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	dir := t.TempDir()
+	var files []string
+	for _, name := range []string{"a.mp3", "b.wav"} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("audio-"+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, p)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/voices/add":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parse form: %v", err)
+			}
+			if r.FormValue("name") != "Captain" || r.FormValue("labels") != `{"accent":"british"}` {
+				t.Fatalf("unexpected form %v", r.MultipartForm.Value)
+			}
+			if got := len(r.MultipartForm.File["files"]); got != 2 {
+				t.Fatalf("expected 2 samples, got %d", got)
+			}
+			_, _ = w.Write([]byte(`{"voice_id":"new1","requires_verification":false}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/voices/new1":
+			_, _ = w.Write([]byte(`{"voice_id":"new1","name":"Captain","category":"cloned","labels":{"accent":"british"}}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	out, err := executeRoot(t, srv.URL, "voices", "add", "--name", "Captain", "--file", files[0], "--file", files[1], "--label", "accent=british")
+	if err != nil {
+		t.Fatalf("voices add: %v", err)
+	}
+	if strings.TrimSpace(out) != "new1" {
+		t.Fatalf("expected voice ID on stdout, got %q", out)
+	}
+	cached, ok := loadTestVoiceCache(t).Voices["new1"]
+	if !ok || cached.Voice.Labels["accent"] != "british" {
+		t.Fatalf("voice cache not warmed: %+v", cached)
+	}
+}
+
+func TestVoicesEditMergesLabels(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	var edited bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/voices/"+testVoiceID:
+			_, _ = w.Write([]byte(`{"voice_id":"` + testVoiceID + `","name":"Captain","description":"sea dog","labels":{"accent":"british","age":"young"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/voices/"+testVoiceID+"/edit":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parse form: %v", err)
+			}
+			var labels map[string]string
+			if err := json.Unmarshal([]byte(r.FormValue("labels")), &labels); err != nil {
+				t.Fatalf("labels: %v", err)
+			}
+			if r.FormValue("name") != "Captain" || r.FormValue("description") != "sea dog" {
+				t.Fatalf("existing fields not preserved: %v", r.MultipartForm.Value)
+			}
+			if len(labels) != 1 || labels["age"] != "old" {
+				t.Fatalf("unexpected labels %v", labels)
+			}
+			edited = true
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	if _, err := executeRoot(t, srv.URL, "voices", "edit", testVoiceID, "--label", "age=old", "--label", "accent="); err != nil {
+		t.Fatalf("voices edit: %v", err)
+	}
+	if !edited {
+		t.Fatal("edit endpoint not called")
+	}
+}
+
+func TestVoicesDeleteAsksForConfirmation(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	updateVoiceCache(func(cache *voiceCache) { cache.Voices[testVoiceID] = cachedVoice{} })
+	var deleted int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"voice_id":"` + testVoiceID + `","name":"Captain"}`))
+		case http.MethodDelete:
+			deleted++
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		}
+	}))
+	defer srv.Close()
+	defer func() { promptInput = os.Stdin }()
+
+	promptInput = strings.NewReader("n\n")
+	if _, err := executeRoot(t, srv.URL, "voices", "delete", testVoiceID); err == nil || deleted != 0 {
+		t.Fatalf("expected abort without deleting, err=%v deleted=%d", err, deleted)
+	}
+
+	promptInput = strings.NewReader("y\n")
+	if _, err := executeRoot(t, srv.URL, "voices", "delete", testVoiceID); err != nil || deleted != 1 {
+		t.Fatalf("expected delete, err=%v deleted=%d", err, deleted)
+	}
+	if _, ok := loadTestVoiceCache(t).Voices[testVoiceID]; ok {
+		t.Fatal("deleted voice still cached")
+	}
+}
+
+func TestParseVoiceLabels(t *testing.T) {
+	labels, err := parseVoiceLabels([]string{"Accent=British", " age = old ", "gone="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels["Accent"] != "British" || labels["age"] != "old" || labels["gone"] != "" {
+		t.Fatalf("unexpected labels %v", labels)
+	}
+	if _, err := parseVoiceLabels([]string{"novalue"}); err == nil {
+		t.Fatal("expected error for label without =")
+	}
+}
+
+func TestVoicesDeleteRefusesPartialName(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	var deleted int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/voices":
+			_, _ = w.Write([]byte(`{"voices":[{"voice_id":"id-captain","name":"Captain"},{"voice_id":"id-cap-a","name":"Cap"},{"voice_id":"id-cap-b","name":"cap"}]}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"voice_id":"id-captain","name":"Captain"}`))
+		case r.Method == http.MethodDelete:
+			deleted++
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		}
+	}))
+	defer srv.Close()
+
+	_, err := executeRoot(t, srv.URL, "voices", "delete", "--yes", "Capt")
+	if err == nil || !strings.Contains(err.Error(), "no voice named exactly") || deleted != 0 {
+		t.Fatalf("expected partial name to be refused, err=%v deleted=%d", err, deleted)
+	}
+	_, err = executeRoot(t, srv.URL, "voices", "delete", "--yes", "CAP")
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") || deleted != 0 {
+		t.Fatalf("expected ambiguous name to be refused, err=%v deleted=%d", err, deleted)
+	}
+	if _, err := executeRoot(t, srv.URL, "voices", "delete", "--yes", "captain"); err != nil || deleted != 1 {
+		t.Fatalf("expected exact name to delete, err=%v deleted=%d", err, deleted)
+	}
+}
@@ -0,0 +1,81 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+// WAVCapture records what would have played as 16-bit stereo WAV. The first clip truncates the
+// file and sets its sample rate; later clips are appended, resampled to that rate. The header is
+// rewritten after every clip, so the file is valid between plays.
+type WAVCapture struct {
+	path string
+
+	mu       sync.Mutex
+	started  bool
+	rate     int
+	dataSize int64
+}
+
+// NewWAVCapture returns a capture backend writing to path.
+func NewWAVCapture(path string) *WAVCapture {
+	return &WAVCapture{path: path}
+}
+
+// Play decodes r and appends it to the capture file instead of playing it.
+func (c *WAVCapture) Play(ctx context.Context, r io.Reader, format string) error {
+	pcm, rate, err := decode(r, format)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	flags := os.O_RDWR | os.O_CREATE
+	if !c.started {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(c.path, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("wav-capture: %w", err)
+	}
+	if !c.started {
+		c.started = true
+		c.rate = rate
+		c.dataSize = 0
+		if _, err := f.Write(wavHeader(c.rate, 2, 0)); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("wav-capture: %w", err)
+		}
+	}
+	if rate != c.rate {
+		pcm = newResampler(pcm, rate, c.rate)
+	}
+
+	if _, err := f.Seek(wavHeaderSize+c.dataSize, io.SeekStart); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("wav-capture: %w", err)
+	}
+	n, copyErr := io.Copy(f, pcm)
+	// Drop a truncated trailing frame so the next clip stays frame-aligned.
+	c.dataSize += n - n%4
+	err = f.Truncate(wavHeaderSize + c.dataSize)
+	if err == nil {
+		_, err = f.WriteAt(wavHeader(c.rate, 2, uint32(min(c.dataSize, math.MaxUint32-wavHeaderSize))), 0)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if copyErr != nil {
+		return fmt.Errorf("wav-capture: %w", copyErr)
+	}
+	if err != nil {
+		return fmt.Errorf("wav-capture: %w", err)
+	}
+	return ctx.Err()
+}
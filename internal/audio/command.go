@@ -0,0 +1,129 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// playerTemplates are used when a known player is named without arguments.
+var playerTemplates = map[string]string{
+	"paplay": "paplay --raw --format=s16le --rate={rate} --channels={channels}",
+	"aplay":  "aplay -q -t raw -f S16_LE -r {rate} -c {channels} -",
+	"ffplay": "ffplay -nodisp -autoexit -loglevel error -",
+	"afplay": "afplay {file}",
+}
+
+// Command plays audio through an external player.
+type Command struct {
+	args []string
+}
+
+// NewCommand returns a backend for a player command line, split on whitespace (no shell).
+//
+// paplay, aplay, ffplay, and afplay may be named without arguments. Arguments may contain the
+// placeholders {rate}, {channels}, and {file}: a command that uses {file} gets the path of a
+// temporary WAV file, one that uses {rate} reads raw signed 16-bit little-endian samples on
+// stdin, and any other command reads a WAV stream on stdin.
+func NewCommand(command string) (*Command, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, errors.New("player command is empty")
+	}
+	if len(fields) == 1 {
+		if tmpl, ok := playerTemplates[filepath.Base(fields[0])]; ok {
+			fields = append([]string{fields[0]}, strings.Fields(tmpl)[1:]...)
+		}
+	}
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return nil, fmt.Errorf("player %s: %w", fields[0], err)
+	}
+	return &Command{args: fields}, nil
+}
+
+// Play decodes r and feeds it to the player command, waiting for the player to exit.
+func (c *Command) Play(ctx context.Context, r io.Reader, format string) error {
+	// Drain what the player leaves unread so the writer feeding r is never stuck.
+	defer func() { _, _ = io.Copy(io.Discard, r) }()
+
+	pcm, rate, err := decode(r, format)
+	if err != nil {
+		return err
+	}
+
+	template := strings.Join(c.args, " ")
+	var file string
+	var stdin io.Reader
+	switch {
+	case strings.Contains(template, "{file}"):
+		if file, err = writeTempWAV(pcm, rate); err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(file) }()
+	case strings.Contains(template, "{rate}"):
+		stdin = pcm
+	default:
+		stdin = io.MultiReader(bytes.NewReader(wavHeader(rate, 2, math.MaxUint32)), pcm)
+	}
+
+	args := c.commandArgs(rate, 2, file)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = stdin
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("player %s: %w: %s", filepath.Base(args[0]), err, msg)
+		}
+		return fmt.Errorf("player %s: %w", filepath.Base(args[0]), err)
+	}
+	return nil
+}
+
+func (c *Command) commandArgs(rate, channels int, file string) []string {
+	replacer := strings.NewReplacer(
+		"{rate}", strconv.Itoa(rate),
+		"{channels}", strconv.Itoa(channels),
+		"{file}", file,
+	)
+	args := make([]string, len(c.args))
+	for i, a := range c.args {
+		args[i] = replacer.Replace(a)
+	}
+	return args
+}
+
+// writeTempWAV stores stereo PCM in a temporary WAV file for players that cannot read stdin.
+func writeTempWAV(pcm io.Reader, rate int) (string, error) {
+	f, err := os.CreateTemp("", "sag-*.wav")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(wavHeader(rate, 2, 0))
+	var n int64
+	if err == nil {
+		n, err = io.Copy(f, pcm)
+	}
+	if err == nil {
+		_, err = f.WriteAt(wavHeader(rate, 2, uint32(min(n-n%4, math.MaxUint32-wavHeaderSize))), 0)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
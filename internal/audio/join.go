@@ -0,0 +1,79 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// JoinWAV concatenates 16-bit PCM WAV clips into one WAV with gap of silence between them.
+// Clips that differ in sample rate or channel count are converted to stereo at the first clip's rate.
+func JoinWAV(parts [][]byte, gap time.Duration) ([]byte, error) {
+	if len(parts) == 0 {
+		return nil, errors.New("no clips to join")
+	}
+	formats := make([]wavFormat, len(parts))
+	samples := make([][]byte, len(parts))
+	same := true
+	for i, p := range parts {
+		format, data, err := splitWAV(p)
+		if err != nil {
+			return nil, fmt.Errorf("clip %d: decode wav: %w", i+1, err)
+		}
+		formats[i], samples[i] = format, data
+		same = same && format == formats[0]
+	}
+	rate, channels := formats[0].sampleRate, formats[0].channels
+	if !same {
+		channels = 2
+		for i, f := range formats {
+			var pcm io.Reader = bytes.NewReader(samples[i])
+			if f.channels == 1 {
+				pcm = &monoToStereo{r: pcm}
+			}
+			if f.sampleRate != rate {
+				pcm = newResampler(pcm, f.sampleRate, rate)
+			}
+			var err error
+			if samples[i], err = io.ReadAll(pcm); err != nil {
+				return nil, fmt.Errorf("clip %d: %w", i+1, err)
+			}
+		}
+	}
+
+	blockAlign := channels * 2
+	silence := make([]byte, int(gap.Seconds()*float64(rate))*blockAlign)
+	var body bytes.Buffer
+	for i, s := range samples {
+		if i > 0 {
+			body.Write(silence)
+		}
+		body.Write(s[:len(s)-len(s)%blockAlign])
+	}
+	return append(wavHeader(rate, channels, uint32(body.Len())), body.Bytes()...), nil
+}
+
+// splitWAV returns the format and sample bytes of a 16-bit PCM WAV. Chunks after the data chunk are dropped
+// when its size is known.
+func splitWAV(p []byte) (wavFormat, []byte, error) {
+	r := bytes.NewReader(p)
+	format, err := readWAVHeader(r)
+	if err != nil {
+		return wavFormat{}, nil, err
+	}
+	if format.bitsPerSample != 16 {
+		return wavFormat{}, nil, fmt.Errorf("unsupported bit depth %d (want 16)", format.bitsPerSample)
+	}
+	if format.channels != 1 && format.channels != 2 {
+		return wavFormat{}, nil, fmt.Errorf("unsupported channel count %d", format.channels)
+	}
+	start := len(p) - r.Len()
+	data := p[start:]
+	if size := int64(binary.LittleEndian.Uint32(p[start-4 : start])); size > 0 && size < int64(len(data)) {
+		data = data[:size]
+	}
+	return format, data, nil
+}
@@ -0,0 +1,49 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestJoinWAVSameFormat(t *testing.T) {
+	a := append(wavHeader(1000, 1, 4), 1, 0, 2, 0)
+	// Chunks after the data chunk are not audio.
+	b := append(append(wavHeader(1000, 1, 2), 3, 0), []byte("LIST\x00\x00\x00\x00")...)
+	got, err := JoinWAV([][]byte{a, b}, 3*time.Millisecond)
+	if err != nil {
+		t.Fatalf("JoinWAV: %v", err)
+	}
+	format, err := readWAVHeader(bytes.NewReader(got))
+	if err != nil || format.sampleRate != 1000 || format.channels != 1 {
+		t.Fatalf("unexpected header %+v, %v", format, err)
+	}
+	want := []byte{1, 0, 2, 0, 0, 0, 0, 0, 0, 0, 3, 0}
+	if !bytes.Equal(got[wavHeaderSize:], want) || binary.LittleEndian.Uint32(got[40:44]) != uint32(len(want)) {
+		t.Fatalf("joined % x, want % x", got[wavHeaderSize:], want)
+	}
+}
+
+func TestJoinWAVConvertsMixedFormats(t *testing.T) {
+	a := append(wavHeader(1000, 1, 4), 1, 0, 1, 0)
+	b := append(wavHeader(2000, 2, 16), make([]byte, 16)...)
+	got, err := JoinWAV([][]byte{a, b}, 0)
+	if err != nil {
+		t.Fatalf("JoinWAV: %v", err)
+	}
+	format, err := readWAVHeader(bytes.NewReader(got))
+	if err != nil || format.sampleRate != 1000 || format.channels != 2 {
+		t.Fatalf("unexpected header %+v, %v", format, err)
+	}
+	// 2 mono frames plus 4 frames at twice the rate become 4 stereo frames.
+	if n := len(got) - wavHeaderSize; n != 16 {
+		t.Fatalf("joined %d bytes of samples, want 16", n)
+	}
+}
+
+func TestJoinWAVRejectsNonWAV(t *testing.T) {
+	if _, err := JoinWAV([][]byte{[]byte("RIFF...."), []byte("RIFF....")}, 0); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,57 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Output is a playback backend. Play consumes audio encoded as the named output format
+// ("mp3_44100_128", "pcm_24000", ...; empty means MP3 or WAV, sniffed from the data).
+type Output interface {
+	Play(ctx context.Context, r io.Reader, format string) error
+}
+
+// NewOutput parses a backend spec:
+//
+//	""  or "oto"        the default output device (via oto)
+//	"null"              discard the audio
+//	"wav-capture:PATH"  record the decoded audio to a WAV file instead of playing it
+//	anything else       an external player command, see NewCommand
+func NewOutput(spec string) (Output, error) {
+	spec = strings.TrimSpace(spec)
+	name, arg, hasArg := strings.Cut(spec, ":")
+	switch strings.ToLower(name) {
+	case "", "oto":
+		return Speakers{}, nil
+	case "null":
+		return Null{}, nil
+	case "wav-capture":
+		if !hasArg || strings.TrimSpace(arg) == "" {
+			return nil, errors.New("wav-capture needs a file, e.g. wav-capture:out.wav")
+		}
+		return NewWAVCapture(strings.TrimSpace(arg)), nil
+	}
+	return NewCommand(spec)
+}
+
+// Speakers plays audio on the default output device.
+type Speakers struct{}
+
+// Play plays r on the default output device.
+func (Speakers) Play(ctx context.Context, r io.Reader, format string) error {
+	return StreamFormatToSpeakers(ctx, r, format)
+}
+
+// Null reads and discards the audio, for machines without a sound device.
+type Null struct{}
+
+// Play reads r to the end without decoding it.
+func (Null) Play(ctx context.Context, r io.Reader, _ string) error {
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("null output: %w", err)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,136 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewOutput(t *testing.T) {
+	for spec, want := range map[string]Output{"": Speakers{}, "oto": Speakers{}, "NULL": Null{}} {
+		got, err := NewOutput(spec)
+		if err != nil || got != want {
+			t.Errorf("NewOutput(%q) = %#v, %v", spec, got, err)
+		}
+	}
+	if out, err := NewOutput("wav-capture:out.wav"); err != nil || out.(*WAVCapture).path != "out.wav" {
+		t.Errorf("wav-capture = %#v, %v", out, err)
+	}
+	if _, err := NewOutput("wav-capture"); err == nil {
+		t.Error("wav-capture without a file should fail")
+	}
+	if _, err := NewOutput("sag-no-such-player --flag"); err == nil || !strings.Contains(err.Error(), "sag-no-such-player") {
+		t.Errorf("expected lookup error, got %v", err)
+	}
+}
+
+func TestNullDrainsInput(t *testing.T) {
+	r := strings.NewReader("not audio at all")
+	if err := (Null{}).Play(context.Background(), r, "mp3_44100_128"); err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 0 {
+		t.Fatalf("%d bytes left unread", r.Len())
+	}
+}
+
+func TestWAVCaptureAppendsClipsAtFirstRate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.wav")
+	c := NewWAVCapture(path)
+	ctx := context.Background()
+
+	// pcm_16000 is mono: 4 samples become 4 stereo frames.
+	if err := c.Play(ctx, bytes.NewReader(make([]byte, 8)), "pcm_16000"); err != nil {
+		t.Fatal(err)
+	}
+	// 6 mono samples at 24 kHz last as long as 4 frames at 16 kHz.
+	if err := c.Play(ctx, bytes.NewReader(make([]byte, 12)), "pcm_24000"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	format, err := readWAVHeader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format.sampleRate != 16000 || format.channels != 2 {
+		t.Fatalf("unexpected format %+v", format)
+	}
+	if size := binary.LittleEndian.Uint32(data[40:44]); size != 32 || len(data) != wavHeaderSize+32 {
+		t.Fatalf("data size %d, file %d bytes; want 32 bytes of samples", size, len(data))
+	}
+
+	// A new capture starts the file over.
+	if err := NewWAVCapture(path).Play(ctx, bytes.NewReader(make([]byte, 2)), "pcm_24000"); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ = os.ReadFile(path); len(data) != wavHeaderSize+4 {
+		t.Fatalf("expected a fresh file, got %d bytes", len(data))
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	c := &Command{args: append([]string{"paplay"}, strings.Fields(playerTemplates["paplay"])[1:]...)}
+	want := []string{"paplay", "--raw", "--format=s16le", "--rate=24000", "--channels=2"}
+	if got := c.commandArgs(24000, 2, ""); !slices.Equal(got, want) {
+		t.Fatalf("commandArgs = %q, want %q", got, want)
+	}
+}
+
+func TestCommandPipesWAV(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub player requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	got := filepath.Join(dir, "got")
+	player := filepath.Join(dir, "player")
+	if err := os.WriteFile(player, []byte("#!/bin/sh\ncat > \"$1\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewCommand(player + " " + got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Play(context.Background(), bytes.NewReader([]byte{1, 0, 2, 0}), "pcm_22050"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	format, err := readWAVHeader(bytes.NewReader(data))
+	if err != nil || format.sampleRate != 22050 || format.channels != 2 {
+		t.Fatalf("unexpected header %+v, %v", format, err)
+	}
+	if want := []byte{1, 0, 1, 0, 2, 0, 2, 0}; !bytes.Equal(data[wavHeaderSize:], want) {
+		t.Fatalf("player got % x, want % x", data[wavHeaderSize:], want)
+	}
+}
+
+func TestCommandReportsPlayerFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub player requires a POSIX shell")
+	}
+	player := filepath.Join(t.TempDir(), "player")
+	if err := os.WriteFile(player, []byte("#!/bin/sh\necho 'no device' >&2\nexit 3\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewCommand(player + " --rate={rate}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.Play(context.Background(), bytes.NewReader(make([]byte, 1<<16)), "pcm_16000")
+	if err == nil || !strings.Contains(err.Error(), "no device") {
+		t.Fatalf("expected player error, got %v", err)
+	}
+}
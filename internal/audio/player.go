@@ -1,10 +1,13 @@
 package audio
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,27 +25,86 @@ var (
 	audioContextErr error
 )
 
-// StreamToSpeakers decodes MP3 audio from the reader and plays it to the default output device.
+// pcmSampleRates are the raw PCM rates that can be played without decoding.
+var pcmSampleRates = []int{16000, 22050, 24000, 44100, 48000}
+
+// StreamToSpeakers decodes MP3 or WAV audio from the reader and plays it to the default output device.
 func StreamToSpeakers(ctx context.Context, r io.Reader) error {
-	decoder, err := mp3.NewDecoder(r)
+	return StreamFormatToSpeakers(ctx, r, "")
+}
+
+// StreamFormatToSpeakers plays audio encoded as the named output format. Raw "pcm_<rate>" audio
+// (signed 16-bit little-endian mono) goes to the device without a decode step; other formats
+// are decoded as MP3 or WAV.
+func StreamFormatToSpeakers(ctx context.Context, r io.Reader, format string) error {
+	pcm, rate, err := decode(r, format)
+	if err != nil {
+		return err
+	}
+	return playPCM(ctx, pcm, rate)
+}
+
+// decode turns audio in the named output format into interleaved signed 16-bit little-endian
+// stereo samples and reports their sample rate.
+func decode(r io.Reader, format string) (io.Reader, int, error) {
+	rate, raw, err := pcmSampleRate(format)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Some providers wrap PCM in a WAV header even when asked for raw samples.
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(4); string(magic) == "RIFF" {
+		return decodeWAV(br)
+	}
+	if raw {
+		return &monoToStereo{r: br}, rate, nil
+	}
+
+	decoder, err := mp3.NewDecoder(br)
 	if err != nil {
-		return fmt.Errorf("decode mp3: %w", err)
+		return nil, 0, fmt.Errorf("decode mp3: %w", err)
 	}
+	return decoder, decoder.SampleRate(), nil
+}
 
+// pcmSampleRate parses "pcm_<rate>" formats. raw is false for anything that is not raw PCM.
+func pcmSampleRate(format string) (rate int, raw bool, err error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "pcm" && !strings.HasPrefix(format, "pcm_") {
+		return 0, false, nil
+	}
+	rate, err = strconv.Atoi(strings.TrimPrefix(format, "pcm_"))
+	if err != nil {
+		return 0, true, fmt.Errorf("play %s: raw PCM needs a sample rate, e.g. pcm_24000", format)
+	}
+	for _, r := range pcmSampleRates {
+		if r == rate {
+			return rate, true, nil
+		}
+	}
+	return 0, true, fmt.Errorf("play %s: unsupported PCM sample rate %d", format, rate)
+}
+
+// playPCM plays interleaved signed 16-bit little-endian stereo samples.
+func playPCM(ctx context.Context, src io.Reader, sampleRate int) error {
 	const (
 		channelCount = 2
 		format       = oto.FormatSignedInt16LE
 	)
 
-	audioCtx, ready, err := getAudioContext(decoder.SampleRate(), channelCount, format)
+	audioCtx, ready, contextRate, err := getAudioContext(sampleRate, channelCount, format)
 	if err != nil {
 		return fmt.Errorf("audio context: %w", err)
 	}
 	if ready != nil {
 		<-ready
 	}
+	// The device context is created once per process; later clips at other rates are converted to it.
+	if contextRate != sampleRate {
+		src = newResampler(src, sampleRate, contextRate)
+	}
 
-	player := audioCtx.NewPlayer(decoder)
+	player := audioCtx.NewPlayer(src)
 	defer func() {
 		_ = player.Close()
 	}()
@@ -51,22 +113,21 @@ func StreamToSpeakers(ctx context.Context, r io.Reader) error {
 	return waitForPlayback(ctx, player)
 }
 
-func getAudioContext(sampleRate, channelCount int, format oto.Format) (*oto.Context, chan struct{}, error) {
+// getAudioContext returns the process-wide audio context and its sample rate, creating it at sampleRate
+// on first use.
+func getAudioContext(sampleRate, channelCount int, format oto.Format) (*oto.Context, chan struct{}, int, error) {
 	audioCtxMu.Lock()
 	defer audioCtxMu.Unlock()
 
 	if audioCtx != nil {
 		if audioContextErr != nil {
-			return nil, nil, audioContextErr
-		}
-		if audioSampleRate != sampleRate {
-			return nil, nil, fmt.Errorf("context already initialized at %d Hz; got %d Hz", audioSampleRate, sampleRate)
+			return nil, nil, 0, audioContextErr
 		}
-		return audioCtx, audioReady, nil
+		return audioCtx, audioReady, audioSampleRate, nil
 	}
 
 	if sampleRate <= 0 {
-		return nil, nil, errors.New("invalid sample rate")
+		return nil, nil, 0, errors.New("invalid sample rate")
 	}
 
 	ctx, ready, err := oto.NewContext(&oto.NewContextOptions{
@@ -76,13 +137,13 @@ func getAudioContext(sampleRate, channelCount int, format oto.Format) (*oto.Cont
 	})
 	if err != nil {
 		audioContextErr = err
-		return nil, nil, err
+		return nil, nil, 0, err
 	}
 	audioCtx = ctx
 	audioReady = ready
 	audioSampleRate = sampleRate
 	audioContextErr = nil
-	return audioCtx, audioReady, nil
+	return audioCtx, audioReady, audioSampleRate, nil
 }
 
 func waitForPlayback(ctx context.Context, player *oto.Player) error {
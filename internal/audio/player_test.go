@@ -12,3 +12,32 @@ func TestStreamToSpeakersBadMP3(t *testing.T) {
 		t.Fatalf("expected decode error")
 	}
 }
+
+func TestPCMSampleRate(t *testing.T) {
+	cases := []struct {
+		format  string
+		rate    int
+		raw     bool
+		wantErr bool
+	}{
+		{"mp3_44100_128", 0, false, false},
+		{"wav", 0, false, false},
+		{"pcm_24000", 24000, true, false},
+		{"PCM_16000", 16000, true, false},
+		{"pcm_8000", 0, true, true},
+		{"pcm", 0, true, true},
+	}
+	for _, tc := range cases {
+		rate, raw, err := pcmSampleRate(tc.format)
+		if rate != tc.rate || raw != tc.raw || (err != nil) != tc.wantErr {
+			t.Errorf("pcmSampleRate(%q) = %d, %t, %v", tc.format, rate, raw, err)
+		}
+	}
+}
+
+func TestStreamFormatToSpeakersRejectsUnsupportedPCM(t *testing.T) {
+	err := StreamFormatToSpeakers(context.Background(), strings.NewReader("\x00\x00"), "pcm_8000")
+	if err == nil || !strings.Contains(err.Error(), "unsupported PCM sample rate") {
+		t.Fatalf("expected sample rate error, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package audio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// resampler converts interleaved signed 16-bit little-endian stereo from one sample rate to another
+// by linear interpolation. It streams: only the two source frames around the output position are kept.
+type resampler struct {
+	r        *bufio.Reader
+	from, to int
+	// pos is the output position between prev and next, in 1/to steps of a source frame.
+	pos        int
+	prev, next [2]int16
+	started    bool
+	done       bool
+}
+
+func newResampler(r io.Reader, from, to int) *resampler {
+	return &resampler{r: bufio.NewReader(r), from: from, to: to}
+}
+
+func (s *resampler) Read(p []byte) (int, error) {
+	if len(p) < 4 {
+		return 0, io.ErrShortBuffer
+	}
+	if !s.started {
+		s.started = true
+		if err := s.readFrame(&s.prev); err != nil {
+			return 0, err
+		}
+		if err := s.readFrame(&s.next); err != nil {
+			// A single frame: play it as is.
+			s.next = s.prev
+			s.done = true
+		}
+	}
+
+	n := 0
+	for n+4 <= len(p) {
+		for s.pos >= s.to {
+			if s.done {
+				return s.result(n)
+			}
+			s.prev = s.next
+			if err := s.readFrame(&s.next); err != nil {
+				if !errors.Is(err, io.EOF) {
+					if n > 0 {
+						return n, nil
+					}
+					return 0, err
+				}
+				// Hold the last frame for the rest of its duration.
+				s.next = s.prev
+				s.done = true
+			}
+			s.pos -= s.to
+		}
+		for ch := range 2 {
+			a, b := int(s.prev[ch]), int(s.next[ch])
+			v := a + (b-a)*s.pos/s.to
+			binary.LittleEndian.PutUint16(p[n+ch*2:], uint16(int16(v)))
+		}
+		n += 4
+		s.pos += s.from
+	}
+	return n, nil
+}
+
+func (s *resampler) result(n int) (int, error) {
+	if n > 0 {
+		return n, nil
+	}
+	return 0, io.EOF
+}
+
+// readFrame reads one stereo frame; a truncated trailing frame counts as the end of the stream.
+func (s *resampler) readFrame(f *[2]int16) error {
+	var b [4]byte
+	if _, err := io.ReadFull(s.r, b[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return io.EOF
+		}
+		return err
+	}
+	f[0] = int16(binary.LittleEndian.Uint16(b[0:2]))
+	f[1] = int16(binary.LittleEndian.Uint16(b[2:4]))
+	return nil
+}
@@ -0,0 +1,93 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func stereoFrames(frames ...[2]int16) []byte {
+	out := make([]byte, 0, len(frames)*4)
+	for _, f := range frames {
+		out = binary.LittleEndian.AppendUint16(out, uint16(f[0]))
+		out = binary.LittleEndian.AppendUint16(out, uint16(f[1]))
+	}
+	return out
+}
+
+func readFrames(t *testing.T, r io.Reader) [][2]int16 {
+	t.Helper()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(data)%4 != 0 {
+		t.Fatalf("partial frame in %d bytes", len(data))
+	}
+	frames := make([][2]int16, len(data)/4)
+	for i := range frames {
+		frames[i][0] = int16(binary.LittleEndian.Uint16(data[i*4:]))
+		frames[i][1] = int16(binary.LittleEndian.Uint16(data[i*4+2:]))
+	}
+	return frames
+}
+
+func TestResamplerUpsamplesByInterpolation(t *testing.T) {
+	src := stereoFrames([2]int16{0, 100}, [2]int16{100, -100}, [2]int16{200, 0})
+	got := readFrames(t, newResampler(bytes.NewReader(src), 1, 2))
+	want := [][2]int16{{0, 100}, {50, 0}, {100, -100}, {150, -50}, {200, 0}}
+	if len(got) < len(want) {
+		t.Fatalf("got %v, want prefix %v", got, want)
+	}
+	for i, w := range want {
+		if got[i] != w {
+			t.Fatalf("frame %d = %v, want %v (all: %v)", i, got[i], w, got)
+		}
+	}
+}
+
+func TestResamplerKeepsDurationAcrossRates(t *testing.T) {
+	const frames = 24000
+	src := bytes.Repeat(stereoFrames([2]int16{1000, -1000}), frames)
+	for _, tc := range []struct{ from, to int }{{24000, 48000}, {24000, 44100}, {48000, 22050}} {
+		got := readFrames(t, newResampler(bytes.NewReader(src), tc.from, tc.to))
+		want := frames * tc.to / tc.from
+		if diff := len(got) - want; diff < -2 || diff > 2 {
+			t.Fatalf("%d->%d Hz: %d frames, want about %d", tc.from, tc.to, len(got), want)
+		}
+		for i, f := range got {
+			if f != [2]int16{1000, -1000} {
+				t.Fatalf("%d->%d Hz: frame %d = %v, want a constant signal", tc.from, tc.to, i, f)
+			}
+		}
+	}
+}
+
+func TestResamplerSmallReads(t *testing.T) {
+	r := newResampler(bytes.NewReader(stereoFrames([2]int16{1, 1}, [2]int16{3, 3})), 2, 3)
+	if _, err := r.Read(make([]byte, 3)); err != io.ErrShortBuffer {
+		t.Fatalf("expected short buffer, got %v", err)
+	}
+	var frames int
+	buf := make([]byte, 4)
+	for {
+		n, err := r.Read(buf)
+		frames += n / 4
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if frames != 3 {
+		t.Fatalf("got %d frames, want 3", frames)
+	}
+}
+
+func TestResamplerEmpty(t *testing.T) {
+	if n, err := newResampler(bytes.NewReader(nil), 24000, 48000).Read(make([]byte, 16)); n != 0 || err != io.EOF {
+		t.Fatalf("Read = %d, %v; want EOF", n, err)
+	}
+}
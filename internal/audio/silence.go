@@ -0,0 +1,79 @@
+package audio
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	mp3BitratesV1 = [15]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320}
+	mp3BitratesV2 = [15]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160}
+	mp3Rates      = map[int][3]int{3: {44100, 48000, 32000}, 2: {22050, 24000, 16000}, 0: {11025, 12000, 8000}}
+)
+
+// MP3Silence returns at least d of silent MP3 frames in the same format as the first frame of ref,
+// so the result can be appended to or between streams like ref.
+func MP3Silence(ref []byte, d time.Duration) ([]byte, error) {
+	header, err := firstMP3Header(ref)
+	if err != nil {
+		return nil, err
+	}
+	version := int(header[1]>>3) & 3
+	bitrateIdx := int(header[2] >> 4)
+	rateIdx := int(header[2]>>2) & 3
+	mono := header[3]>>6 == 3
+
+	bitrates, factor, samples, sideInfo := mp3BitratesV1, 144, 1152, 32
+	switch {
+	case version == 3 && mono:
+		sideInfo = 17
+	case version != 3 && mono:
+		bitrates, factor, samples, sideInfo = mp3BitratesV2, 72, 576, 9
+	case version != 3:
+		bitrates, factor, samples, sideInfo = mp3BitratesV2, 72, 576, 17
+	}
+	sampleRate := mp3Rates[version][rateIdx]
+	frameLen := factor * bitrates[bitrateIdx] * 1000 / sampleRate
+	if frameLen < 4+sideInfo {
+		return nil, errors.New("mp3 frame too small for silence")
+	}
+
+	// All-zero side info means no Huffman data: the frame decodes to silence.
+	frame := make([]byte, frameLen)
+	frame[0] = 0xFF
+	frame[1] = header[1] | 0x01  // no CRC
+	frame[2] = header[2] &^ 0x02 // no padding
+	frame[3] = header[3]
+
+	n := int((d.Seconds()*float64(sampleRate) + float64(samples) - 1) / float64(samples))
+	out := make([]byte, 0, n*frameLen)
+	for range n {
+		out = append(out, frame...)
+	}
+	return out, nil
+}
+
+// firstMP3Header returns the header of the first Layer III frame, skipping an ID3v2 tag.
+func firstMP3Header(data []byte) ([4]byte, error) {
+	if len(data) >= 10 && string(data[:3]) == "ID3" {
+		size := int(data[6]&0x7F)<<21 | int(data[7]&0x7F)<<14 | int(data[8]&0x7F)<<7 | int(data[9]&0x7F)
+		if 10+size > len(data) {
+			return [4]byte{}, errors.New("mp3 ends inside its ID3 tag")
+		}
+		data = data[10+size:]
+	}
+	for i := 0; i+4 <= len(data); i++ {
+		if data[i] != 0xFF || data[i+1]&0xE0 != 0xE0 {
+			continue
+		}
+		version := data[i+1] >> 3 & 3
+		layer := data[i+1] >> 1 & 3
+		bitrateIdx := data[i+2] >> 4
+		rateIdx := data[i+2] >> 2 & 3
+		if version == 1 || layer != 1 || bitrateIdx == 0 || bitrateIdx == 15 || rateIdx == 3 {
+			continue
+		}
+		return [4]byte(data[i : i+4]), nil
+	}
+	return [4]byte{}, errors.New("no mp3 frame found")
+}
@@ -0,0 +1,55 @@
+package audio
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+func TestMP3SilenceDecodesToSilence(t *testing.T) {
+	cases := map[string]struct {
+		header     []byte
+		sampleRate int
+		frameLen   int
+	}{
+		"mpeg1 stereo 44.1k 128k": {[]byte{0xFF, 0xFB, 0x90, 0x44}, 44100, 417},
+		"mpeg2 mono 22.05k 32k":   {[]byte{0xFF, 0xF3, 0x40, 0xC4}, 22050, 104},
+	}
+	for name, tc := range cases {
+		ref := append([]byte("ID3\x03\x00\x00\x00\x00\x00\x02xx"), tc.header...)
+		ref = append(ref, make([]byte, 400)...)
+		data, err := MP3Silence(ref, 300*time.Millisecond)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(data)%tc.frameLen != 0 {
+			t.Fatalf("%s: %d bytes is not a whole number of %d-byte frames", name, len(data), tc.frameLen)
+		}
+		dec, err := mp3.NewDecoder(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: decode: %v", name, err)
+		}
+		if dec.SampleRate() != tc.sampleRate {
+			t.Fatalf("%s: sample rate %d", name, dec.SampleRate())
+		}
+		pcm, err := io.ReadAll(dec)
+		if err != nil {
+			t.Fatalf("%s: read: %v", name, err)
+		}
+		if got := time.Duration(len(pcm)/4) * time.Second / time.Duration(tc.sampleRate); got < 300*time.Millisecond {
+			t.Fatalf("%s: decoded only %s", name, got)
+		}
+		if bytes.ContainsFunc(pcm, func(r rune) bool { return r != 0 }) {
+			t.Fatalf("%s: silence is not silent", name)
+		}
+	}
+}
+
+func TestMP3SilenceNeedsFrame(t *testing.T) {
+	if _, err := MP3Silence([]byte("not audio"), time.Second); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,138 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	wavFormatPCM        = 1
+	wavFormatExtensible = 0xFFFE
+)
+
+// wavFormat describes the PCM layout announced by a WAV header.
+type wavFormat struct {
+	sampleRate    int
+	channels      int
+	bitsPerSample int
+}
+
+// decodeWAV reads a 16-bit PCM WAV header and returns the samples as stereo.
+func decodeWAV(r io.Reader) (io.Reader, int, error) {
+	format, err := readWAVHeader(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decode wav: %w", err)
+	}
+	if format.bitsPerSample != 16 {
+		return nil, 0, fmt.Errorf("decode wav: unsupported bit depth %d (want 16)", format.bitsPerSample)
+	}
+	switch format.channels {
+	case 1:
+		return &monoToStereo{r: r}, format.sampleRate, nil
+	case 2:
+		return r, format.sampleRate, nil
+	default:
+		return nil, 0, fmt.Errorf("decode wav: unsupported channel count %d", format.channels)
+	}
+}
+
+// readWAVHeader consumes RIFF chunks up to the start of the data chunk.
+// Chunk sizes of the data chunk are ignored so streamed WAVs with placeholder sizes still play.
+func readWAVHeader(r io.Reader) (wavFormat, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return wavFormat{}, err
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return wavFormat{}, errors.New("missing RIFF/WAVE header")
+	}
+
+	var format wavFormat
+	var haveFormat bool
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return wavFormat{}, fmt.Errorf("read chunk header: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return wavFormat{}, fmt.Errorf("fmt chunk too short (%d bytes)", size)
+			}
+			var fmtChunk [16]byte
+			if _, err := io.ReadFull(r, fmtChunk[:]); err != nil {
+				return wavFormat{}, err
+			}
+			audioFormat := binary.LittleEndian.Uint16(fmtChunk[0:2])
+			if audioFormat != wavFormatPCM && audioFormat != wavFormatExtensible {
+				return wavFormat{}, fmt.Errorf("unsupported encoding %d (want PCM)", audioFormat)
+			}
+			format = wavFormat{
+				channels:      int(binary.LittleEndian.Uint16(fmtChunk[2:4])),
+				sampleRate:    int(binary.LittleEndian.Uint32(fmtChunk[4:8])),
+				bitsPerSample: int(binary.LittleEndian.Uint16(fmtChunk[14:16])),
+			}
+			haveFormat = true
+			if err := skipChunk(r, size-16, size); err != nil {
+				return wavFormat{}, err
+			}
+		case "data":
+			if !haveFormat {
+				return wavFormat{}, errors.New("data chunk before fmt chunk")
+			}
+			return format, nil
+		default:
+			if err := skipChunk(r, size, size); err != nil {
+				return wavFormat{}, err
+			}
+		}
+	}
+}
+
+// skipChunk discards n bytes plus the pad byte RIFF adds after odd-sized chunks.
+func skipChunk(r io.Reader, n, chunkSize int64) error {
+	if chunkSize%2 == 1 {
+		n++
+	}
+	if n <= 0 {
+		return nil
+	}
+	_, err := io.CopyN(io.Discard, r, n)
+	return err
+}
+
+// monoToStereo duplicates each 16-bit mono sample into both stereo channels.
+type monoToStereo struct {
+	r   io.Reader
+	buf []byte
+}
+
+func (m *monoToStereo) Read(p []byte) (int, error) {
+	frames := len(p) / 4
+	if frames == 0 {
+		return 0, io.ErrShortBuffer
+	}
+	if cap(m.buf) < frames*2 {
+		m.buf = make([]byte, frames*2)
+	}
+	in := m.buf[:frames*2]
+	n, err := io.ReadFull(m.r, in)
+	n -= n % 2
+	for i := 0; i < n/2; i++ {
+		lo, hi := in[i*2], in[i*2+1]
+		p[i*4], p[i*4+1] = lo, hi
+		p[i*4+2], p[i*4+3] = lo, hi
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+		if n > 0 {
+			err = nil
+		}
+	}
+	return n * 2, err
+}
@@ -0,0 +1,68 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func testWAVHeader(sampleRate, channels int, extra []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(0xFFFFFFFF))
+	b.WriteString("WAVE")
+	b.WriteString("fmt ")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(16))
+	_ = binary.Write(&b, binary.LittleEndian, uint16(wavFormatPCM))
+	_ = binary.Write(&b, binary.LittleEndian, uint16(channels))
+	_ = binary.Write(&b, binary.LittleEndian, uint32(sampleRate))
+	_ = binary.Write(&b, binary.LittleEndian, uint32(sampleRate*channels*2))
+	_ = binary.Write(&b, binary.LittleEndian, uint16(channels*2))
+	_ = binary.Write(&b, binary.LittleEndian, uint16(16))
+	if extra != nil {
+		b.WriteString("LIST")
+		_ = binary.Write(&b, binary.LittleEndian, uint32(len(extra)))
+		b.Write(extra)
+		if len(extra)%2 == 1 {
+			b.WriteByte(0)
+		}
+	}
+	b.WriteString("data")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(0xFFFFFFFF))
+	return b.Bytes()
+}
+
+func TestReadWAVHeader(t *testing.T) {
+	data := append(testWAVHeader(22050, 1, []byte("odd")), 1, 2, 3, 4)
+	r := bytes.NewReader(data)
+	format, err := readWAVHeader(r)
+	if err != nil {
+		t.Fatalf("readWAVHeader error: %v", err)
+	}
+	if format.sampleRate != 22050 || format.channels != 1 || format.bitsPerSample != 16 {
+		t.Fatalf("unexpected format: %+v", format)
+	}
+	rest, _ := io.ReadAll(r)
+	if !bytes.Equal(rest, []byte{1, 2, 3, 4}) {
+		t.Fatalf("expected reader positioned at samples, got %v", rest)
+	}
+}
+
+func TestReadWAVHeaderRejectsNonWAV(t *testing.T) {
+	if _, err := readWAVHeader(bytes.NewReader([]byte("ID3\x04not a wav file"))); err == nil {
+		t.Fatalf("expected error for non-WAV input")
+	}
+}
+
+func TestMonoToStereo(t *testing.T) {
+	m := &monoToStereo{r: bytes.NewReader([]byte{0x01, 0x02, 0x03, 0x04})}
+	got, err := io.ReadAll(m)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	want := []byte{0x01, 0x02, 0x01, 0x02, 0x03, 0x04, 0x03, 0x04}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("monoToStereo = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+const wavHeaderSize = 44
+
+// WAVWriter wraps raw signed 16-bit little-endian PCM in a WAV container. The header goes out with
+// placeholder sizes so streamed output is playable right away; Close patches the real sizes when the
+// destination can seek. Audio that already starts with a RIFF header is written unchanged.
+type WAVWriter struct {
+	w           io.Writer
+	sampleRate  int
+	channels    int
+	started     bool
+	passthrough bool
+	closed      bool
+	dataSize    int64
+}
+
+// NewWAVWriter returns a writer that adds a WAV header to PCM written to w. Close also closes w if it is an io.Closer.
+func NewWAVWriter(w io.Writer, sampleRate, channels int) *WAVWriter {
+	return &WAVWriter{w: w, sampleRate: sampleRate, channels: channels}
+}
+
+// Write writes PCM samples. The header goes out lazily with the first non-empty write; if that write
+// starts with a RIFF header, the input is already WAV and passes through unchanged.
+func (w *WAVWriter) Write(p []byte) (int, error) {
+	if !w.started && len(p) > 0 {
+		w.started = true
+		w.passthrough = bytes.HasPrefix(p, []byte("RIFF"))
+		if !w.passthrough {
+			if err := w.writeHeader(math.MaxUint32); err != nil {
+				return 0, err
+			}
+		}
+	}
+	n, err := w.w.Write(p)
+	w.dataSize += int64(n)
+	return n, err
+}
+
+// Close finalizes the header sizes (when w can seek) and closes the underlying writer.
+func (w *WAVWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	err := w.finish()
+	if c, ok := w.w.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func (w *WAVWriter) finish() error {
+	if !w.started {
+		// Nothing was written: still leave a valid, empty WAV file.
+		w.started = true
+		return w.writeHeader(0)
+	}
+	ws, ok := w.w.(io.WriteSeeker)
+	if w.passthrough || !ok {
+		return nil
+	}
+	if _, err := ws.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := w.writeHeader(uint32(min(w.dataSize, math.MaxUint32-wavHeaderSize))); err != nil {
+		return err
+	}
+	_, err := ws.Seek(0, io.SeekEnd)
+	return err
+}
+
+func (w *WAVWriter) writeHeader(dataSize uint32) error {
+	_, err := w.w.Write(wavHeader(w.sampleRate, w.channels, dataSize))
+	return err
+}
+
+// wavHeader builds a 16-bit PCM WAV header announcing dataSize bytes of samples.
+func wavHeader(sampleRate, channels int, dataSize uint32) []byte {
+	const bitsPerSample = 16
+	blockAlign := channels * bitsPerSample / 8
+	riffSize := dataSize
+	if dataSize < math.MaxUint32-wavHeaderSize {
+		riffSize = dataSize + wavHeaderSize - 8
+	}
+
+	h := make([]byte, wavHeaderSize)
+	copy(h[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:8], riffSize)
+	copy(h[8:12], "WAVE")
+	copy(h[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(h[16:20], 16)
+	binary.LittleEndian.PutUint16(h[20:22], wavFormatPCM)
+	binary.LittleEndian.PutUint16(h[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(h[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(h[28:32], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(h[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(h[34:36], bitsPerSample)
+	copy(h[36:40], "data")
+	binary.LittleEndian.PutUint32(h[40:44], dataSize)
+	return h
+}
@@ -0,0 +1,89 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWAVWriterPatchesSizesOnClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWAVWriter(f, 24000, 1)
+	for _, chunk := range [][]byte{{1, 0, 2, 0}, {3, 0}} {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != wavHeaderSize+6 {
+		t.Fatalf("unexpected file size %d", len(data))
+	}
+	if got := binary.LittleEndian.Uint32(data[4:8]); got != 36+6 {
+		t.Fatalf("riff size = %d", got)
+	}
+	if got := binary.LittleEndian.Uint32(data[40:44]); got != 6 {
+		t.Fatalf("data size = %d", got)
+	}
+	format, err := readWAVHeader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("read header: %v", err)
+	}
+	if format != (wavFormat{sampleRate: 24000, channels: 1, bitsPerSample: 16}) {
+		t.Fatalf("unexpected format %+v", format)
+	}
+}
+
+func TestWAVWriterStreamsWithPlaceholderSizes(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWAVWriter(&buf, 16000, 1)
+	if _, err := w.Write([]byte{0, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := binary.LittleEndian.Uint32(buf.Bytes()[40:44]); got != 0xFFFFFFFF {
+		t.Fatalf("expected placeholder data size, got %d", got)
+	}
+	if _, err := readWAVHeader(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("streamed header should still parse: %v", err)
+	}
+}
+
+func TestWAVWriterPassesThroughWAV(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWAVWriter(&buf, 44100, 1)
+	in := []byte("RIFF\x00\x00\x00\x00WAVE")
+	if _, err := w.Write(in); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), in) {
+		t.Fatalf("expected WAV input unchanged, got %q", buf.Bytes())
+	}
+}
+
+func TestWAVWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewWAVWriter(&buf, 22050, 1).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != wavHeaderSize || binary.LittleEndian.Uint32(buf.Bytes()[40:44]) != 0 {
+		t.Fatalf("expected an empty WAV header, got %d bytes", buf.Len())
+	}
+}
@@ -0,0 +1,139 @@
+package chunker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type unit struct {
+	text string
+	sep  string // joiner placed before this unit when packed after another one
+}
+
+// Split breaks text into chunks of at most limit runes, preferring paragraph breaks, then sentence
+// ends, then spaces. Words longer than limit are cut. A limit <= 0 returns the text unchanged.
+func Split(text string, limit int) []string {
+	text = strings.TrimSpace(text)
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	var units []unit
+	for i, para := range paragraphs(text) {
+		sep := "\n\n"
+		if i == 0 {
+			sep = ""
+		}
+		if utf8.RuneCountInString(para) <= limit {
+			units = append(units, unit{para, sep})
+			continue
+		}
+		for _, sentence := range sentences(para) {
+			if utf8.RuneCountInString(sentence) <= limit {
+				units = append(units, unit{sentence, sep})
+			} else {
+				for _, piece := range words(sentence, limit) {
+					units = append(units, unit{piece, sep})
+					sep = " "
+				}
+			}
+			sep = " "
+		}
+	}
+
+	var chunks []string
+	var cur strings.Builder
+	curLen := 0
+	for _, u := range units {
+		n := utf8.RuneCountInString(u.text)
+		if curLen > 0 && curLen+utf8.RuneCountInString(u.sep)+n > limit {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+			curLen = 0
+		}
+		if curLen > 0 {
+			cur.WriteString(u.sep)
+			curLen += utf8.RuneCountInString(u.sep)
+		}
+		cur.WriteString(u.text)
+		curLen += n
+	}
+	if curLen > 0 {
+		chunks = append(chunks, cur.String())
+	}
+	return chunks
+}
+
+func paragraphs(text string) []string {
+	var out []string
+	for _, p := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// sentences splits after ., !, ? or … (plus closing quotes/brackets) when followed by whitespace,
+// and at line breaks.
+func sentences(para string) []string {
+	var out []string
+	runes := []rune(para)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		end := -1
+		switch {
+		case runes[i] == '\n':
+			end = i
+		case strings.ContainsRune(".!?…", runes[i]):
+			j := i + 1
+			for j < len(runes) && strings.ContainsRune(`"')]»”’`, runes[j]) {
+				j++
+			}
+			if j == len(runes) || unicode.IsSpace(runes[j]) {
+				end = j
+				i = j - 1
+			}
+		}
+		if end >= 0 {
+			if s := strings.TrimSpace(string(runes[start:end])); s != "" {
+				out = append(out, s)
+			}
+			start = end
+		}
+	}
+	if s := strings.TrimSpace(string(runes[start:])); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+// words packs a long sentence into pieces of at most limit runes.
+func words(sentence string, limit int) []string {
+	var out []string
+	var cur []rune
+	for _, w := range strings.Fields(sentence) {
+		wr := []rune(w)
+		for len(wr) > limit {
+			if len(cur) > 0 {
+				out = append(out, string(cur))
+				cur = nil
+			}
+			out = append(out, string(wr[:limit]))
+			wr = wr[limit:]
+		}
+		if len(cur) > 0 && len(cur)+1+len(wr) > limit {
+			out = append(out, string(cur))
+			cur = nil
+		}
+		if len(cur) > 0 {
+			cur = append(cur, ' ')
+		}
+		cur = append(cur, wr...)
+	}
+	if len(cur) > 0 {
+		out = append(out, string(cur))
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package chunker
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitShortTextUnchanged(t *testing.T) {
+	got := Split("  Hello there.  ", 100)
+	if len(got) != 1 || got[0] != "Hello there." {
+		t.Fatalf("Split short = %q", got)
+	}
+	if got := Split("anything", 0); len(got) != 1 {
+		t.Fatalf("Split without limit = %q", got)
+	}
+}
+
+func TestSplitPrefersParagraphs(t *testing.T) {
+	text := "First paragraph here.\n\nSecond paragraph here.\n\nThird one."
+	got := Split(text, 45)
+	want := []string{"First paragraph here.\n\nSecond paragraph here.", "Third one."}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("Split = %q, want %q", got, want)
+	}
+}
+
+func TestSplitLongParagraphAtSentences(t *testing.T) {
+	text := "One sentence here. Another “quoted.” Third sentence! Fourth?"
+	got := Split(text, 30)
+	want := []string{"One sentence here.", "Another “quoted.”", "Third sentence! Fourth?"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("Split = %q, want %q", got, want)
+	}
+}
+
+func TestSplitFallsBackToWordsAndHardCuts(t *testing.T) {
+	text := "alpha beta gamma delta epsilon " + strings.Repeat("x", 25)
+	got := Split(text, 12)
+	for _, c := range got {
+		if utf8.RuneCountInString(c) > 12 {
+			t.Fatalf("chunk %q exceeds limit", c)
+		}
+	}
+	if strings.ReplaceAll(strings.Join(got, ""), " ", "") != strings.ReplaceAll(text, " ", "") {
+		t.Fatalf("Split lost text: %q", got)
+	}
+}
@@ -0,0 +1,2 @@
+// Package chunker splits long text into request-sized chunks at paragraph, sentence, or word boundaries.
+package chunker
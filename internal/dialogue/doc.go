@@ -0,0 +1,2 @@
+// Package dialogue parses multi-speaker scripts: a YAML header mapping speakers to voices, then SPEAKER: line turns.
+package dialogue
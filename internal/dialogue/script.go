@@ -0,0 +1,222 @@
+package dialogue
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Script is a parsed dialogue.
+type Script struct {
+	// Model and Gap are optional header defaults; command-line flags take precedence.
+	Model    string
+	Gap      string
+	Speakers map[string]Speaker
+	Lines    []Line
+}
+
+// Speaker is the voice and per-speaker settings for one name in the header.
+// Settings keys are speak flag names (e.g. stability, style, speed); values keep their YAML text.
+type Speaker struct {
+	Voice    string
+	Settings map[string][]string
+}
+
+// Line is one turn of the dialogue.
+type Line struct {
+	Speaker string
+	Text    string
+	// Number is the 1-based line in the script where the turn starts.
+	Number int
+}
+
+type header struct {
+	Model  string               `yaml:"model"`
+	Gap    string               `yaml:"gap"`
+	Voices map[string]yaml.Node `yaml:"voices"`
+}
+
+var turnPattern = regexp.MustCompile(`^([^\s:][^:]{0,39}):\s*(.*)$`)
+
+// Parse reads a script. The optional header sits between two "---" lines:
+//
+//	---
+//	voices:
+//	  ALICE: Rachel
+//	  BOB: {voice: Roger, stability: 0.5}
+//	---
+//	ALICE: Welcome back to the show.
+//	BOB: Thanks for having me.
+//
+// Indented lines continue the previous turn; blank lines and lines starting with # are ignored.
+// Without a voices header, speaker names are used as voice names.
+func Parse(r io.Reader) (Script, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), " \t\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return Script{}, err
+	}
+
+	script := Script{Speakers: map[string]Speaker{}}
+	body := 0
+	for body < len(lines) && strings.TrimSpace(lines[body]) == "" {
+		body++
+	}
+	if body < len(lines) && lines[body] == "---" {
+		end := body + 1
+		for end < len(lines) && lines[end] != "---" {
+			end++
+		}
+		if end == len(lines) {
+			return Script{}, errors.New("header starting with --- is not closed")
+		}
+		if err := script.parseHeader(strings.Join(lines[body+1:end], "\n")); err != nil {
+			return Script{}, fmt.Errorf("header: %w", err)
+		}
+		body = end + 1
+	}
+
+	for i := body; i < len(lines); i++ {
+		raw := lines[i]
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if raw[0] == ' ' || raw[0] == '\t' {
+			if len(script.Lines) == 0 {
+				return Script{}, fmt.Errorf("line %d: continuation before the first speaker", i+1)
+			}
+			last := &script.Lines[len(script.Lines)-1]
+			last.Text = strings.TrimSpace(last.Text + " " + trimmed)
+			continue
+		}
+		m := turnPattern.FindStringSubmatch(raw)
+		if m == nil {
+			return Script{}, fmt.Errorf("line %d: expected SPEAKER: text", i+1)
+		}
+		name, err := script.speakerName(strings.TrimSpace(m[1]))
+		if err != nil {
+			return Script{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		script.Lines = append(script.Lines, Line{Speaker: name, Text: strings.TrimSpace(m[2]), Number: i + 1})
+	}
+
+	turns := script.Lines[:0]
+	for _, l := range script.Lines {
+		if l.Text != "" {
+			turns = append(turns, l)
+		}
+	}
+	script.Lines = turns
+	if len(script.Lines) == 0 {
+		return Script{}, errors.New("script has no lines")
+	}
+	return script, nil
+}
+
+func (s *Script) parseHeader(doc string) error {
+	var h header
+	if err := yaml.Unmarshal([]byte(doc), &h); err != nil {
+		return err
+	}
+	s.Model, s.Gap = strings.TrimSpace(h.Model), strings.TrimSpace(h.Gap)
+	for name, node := range h.Voices {
+		sp, err := parseSpeaker(&node)
+		if err != nil {
+			return fmt.Errorf("speaker %s: %w", name, err)
+		}
+		s.Speakers[strings.TrimSpace(name)] = sp
+	}
+	return nil
+}
+
+// parseSpeaker accepts either a voice ("ALICE: Rachel") or a mapping with voice and settings.
+func parseSpeaker(node *yaml.Node) (Speaker, error) {
+	sp := Speaker{Settings: map[string][]string{}}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		sp.Voice = strings.TrimSpace(node.Value)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := strings.TrimSpace(node.Content[i].Value), node.Content[i+1]
+			switch val.Kind {
+			case yaml.ScalarNode:
+				if key == "voice" {
+					sp.Voice = strings.TrimSpace(val.Value)
+				} else {
+					sp.Settings[key] = []string{val.Value}
+				}
+			case yaml.SequenceNode:
+				for _, item := range val.Content {
+					if item.Kind != yaml.ScalarNode {
+						return Speaker{}, fmt.Errorf("%s: expected a list of values", key)
+					}
+					sp.Settings[key] = append(sp.Settings[key], item.Value)
+				}
+			default:
+				return Speaker{}, fmt.Errorf("%s: expected a value or list", key)
+			}
+		}
+	default:
+		return Speaker{}, errors.New("expected a voice or a mapping with voice and settings")
+	}
+	if sp.Voice == "" {
+		return Speaker{}, errors.New("voice is empty")
+	}
+	return sp, nil
+}
+
+// speakerName maps a turn's speaker to its header entry (case-insensitively).
+// Without a voices header, any name is accepted and used as its own voice.
+func (s *Script) speakerName(name string) (string, error) {
+	if len(s.Speakers) == 0 {
+		return name, nil
+	}
+	if _, ok := s.Speakers[name]; ok {
+		return name, nil
+	}
+	for known := range s.Speakers {
+		if strings.EqualFold(known, name) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("speaker %q has no voice in the header (known: %s)", name, strings.Join(s.SpeakerNames(), ", "))
+}
+
+// SpeakerNames lists the header's speakers, sorted.
+func (s Script) SpeakerNames() []string {
+	names := make([]string, 0, len(s.Speakers))
+	for name := range s.Speakers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Voice returns the voice for a speaker, falling back to the name itself.
+func (s Script) Voice(speaker string) string {
+	if sp, ok := s.Speakers[speaker]; ok {
+		return sp.Voice
+	}
+	return speaker
+}
+
+// HasSettings reports whether any speaker sets more than a voice.
+func (s Script) HasSettings() bool {
+	for _, sp := range s.Speakers {
+		if len(sp.Settings) > 0 {
+			return true
+		}
+	}
+	return false
+}
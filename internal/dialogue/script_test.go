@@ -0,0 +1,75 @@
+package dialogue
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWithHeader(t *testing.T) {
+	script, err := Parse(strings.NewReader(`
+---
+model: eleven_multilingual_v2
+gap: 500ms
+voices:
+  ALICE: Rachel
+  Bob:
+    voice: Roger
+    stability: 0.5
+    tone: [sag/sagg, omg/oh my god]
+---
+# cold open
+ALICE: Welcome back to the show.
+bob: Thanks for having me.
+  Great to be here.
+
+ALICE: Let's start: what is sag?
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if script.Model != "eleven_multilingual_v2" || script.Gap != "500ms" {
+		t.Fatalf("header defaults = %q %q", script.Model, script.Gap)
+	}
+	want := []Line{
+		{Speaker: "ALICE", Text: "Welcome back to the show.", Number: 13},
+		{Speaker: "Bob", Text: "Thanks for having me. Great to be here.", Number: 14},
+		{Speaker: "ALICE", Text: "Let's start: what is sag?", Number: 17},
+	}
+	if !reflect.DeepEqual(script.Lines, want) {
+		t.Fatalf("lines = %+v", script.Lines)
+	}
+	bob := script.Speakers["Bob"]
+	if bob.Voice != "Roger" || !reflect.DeepEqual(bob.Settings, map[string][]string{"stability": {"0.5"}, "tone": {"sag/sagg", "omg/oh my god"}}) {
+		t.Fatalf("bob = %+v", bob)
+	}
+	if !script.HasSettings() || script.Voice("ALICE") != "Rachel" {
+		t.Fatalf("unexpected speakers %+v", script.Speakers)
+	}
+}
+
+func TestParseWithoutHeaderUsesNamesAsVoices(t *testing.T) {
+	script, err := Parse(strings.NewReader("Rachel: Hi.\nRoger: Hello.\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(script.Lines) != 2 || script.Voice("Roger") != "Roger" || script.HasSettings() {
+		t.Fatalf("unexpected script %+v", script)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown speaker": "---\nvoices:\n  ALICE: Rachel\n---\nBOB: hi\n",
+		"unclosed header": "---\nvoices:\n  ALICE: Rachel\n",
+		"no speaker":      "just some prose\n",
+		"no lines":        "# nothing\n",
+		"empty voice":     "---\nvoices:\n  ALICE: {stability: 0.5}\n---\nALICE: hi\n",
+		"leading indent":  "  hello\n",
+	}
+	for name, in := range cases {
+		if _, err := Parse(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
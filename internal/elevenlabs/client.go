@@ -195,6 +195,12 @@ type TTSRequest struct {
 	Seed                   *uint32        `json:"seed,omitempty"`
 	ApplyTextNormalization string         `json:"apply_text_normalization,omitempty"`
 	LanguageCode           string         `json:"language_code,omitempty"`
+	// PronunciationDictionaryLocators applies up to MaxPronunciationDictionaries dictionaries.
+	PronunciationDictionaryLocators []PronunciationDictionaryLocator `json:"pronunciation_dictionary_locators,omitempty"`
+	// PreviousText, NextText and PreviousRequestIDs keep prosody continuous across chunked requests.
+	PreviousText       string   `json:"previous_text,omitempty"`
+	NextText           string   `json:"next_text,omitempty"`
+	PreviousRequestIDs []string `json:"previous_request_ids,omitempty"`
 }
 
 // VoiceSettings tunes synthesis parameters for a request.
@@ -208,9 +214,16 @@ type VoiceSettings struct {
 
 // StreamTTS requests streaming audio for text-to-speech.
 func (c *Client) StreamTTS(ctx context.Context, voiceID string, payload TTSRequest, latency int) (io.ReadCloser, error) {
+	body, _, err := c.StreamTTSWithRequestID(ctx, voiceID, payload, latency)
+	return body, err
+}
+
+// StreamTTSWithRequestID is StreamTTS that also returns the request-id response header,
+// which later requests can pass in PreviousRequestIDs.
+func (c *Client) StreamTTSWithRequestID(ctx context.Context, voiceID string, payload TTSRequest, latency int) (io.ReadCloser, string, error) {
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	u.Path = path.Join(u.Path, "/v1/text-to-speech", voiceID, "stream")
 	if latency > 0 {
@@ -221,12 +234,12 @@ func (c *Client) StreamTTS(ctx context.Context, voiceID string, payload TTSReque
 
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bodyBytes))
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "audio/mpeg")
@@ -234,16 +247,16 @@ func (c *Client) StreamTTS(ctx context.Context, voiceID string, payload TTSReque
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if resp.StatusCode >= 400 {
 		defer func() {
 			_ = resp.Body.Close()
 		}()
 		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("stream TTS failed: %s: %s", resp.Status, string(b))
+		return nil, "", fmt.Errorf("stream TTS failed: %s: %s", resp.Status, string(b))
 	}
-	return resp.Body, nil
+	return resp.Body, resp.Header.Get("request-id"), nil
 }
 
 // ConvertTTS downloads the full audio before returning.
@@ -239,3 +239,33 @@ func TestConvertTTS_Error(t *testing.T) {
 		t.Fatalf("expected 500 error, got %v", err)
 	}
 }
+
+func TestStreamTTSWithRequestIDSendsStitchingContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["previous_text"] != "Before." || body["next_text"] != "After." {
+			t.Fatalf("missing stitching text: %+v", body)
+		}
+		ids, _ := body["previous_request_ids"].([]any)
+		if len(ids) != 1 || ids[0] != "req-0" {
+			t.Fatalf("unexpected previous_request_ids: %+v", body["previous_request_ids"])
+		}
+		w.Header().Set("request-id", "req-1")
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	payload := TTSRequest{Text: "Now.", PreviousText: "Before.", NextText: "After.", PreviousRequestIDs: []string{"req-0"}}
+	body, id, err := c.StreamTTSWithRequestID(context.Background(), "v1", payload, 0)
+	if err != nil {
+		t.Fatalf("StreamTTSWithRequestID error: %v", err)
+	}
+	defer func() { _ = body.Close() }()
+	if id != "req-1" {
+		t.Fatalf("request id = %q, want req-1", id)
+	}
+}
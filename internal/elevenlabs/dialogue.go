@@ -0,0 +1,74 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// DialogueInput is one line of a dialogue and the voice that speaks it.
+type DialogueInput struct {
+	Text    string `json:"text"`
+	VoiceID string `json:"voice_id"`
+}
+
+// DialogueSettings are the scene-wide settings of a dialogue request.
+type DialogueSettings struct {
+	Stability *float64 `json:"stability,omitempty"`
+}
+
+// DialogueRequest renders several voices in one request. Nil fields use the API defaults.
+type DialogueRequest struct {
+	Inputs                          []DialogueInput                  `json:"inputs"`
+	ModelID                         string                           `json:"model_id,omitempty"`
+	LanguageCode                    string                           `json:"language_code,omitempty"`
+	Settings                        *DialogueSettings                `json:"settings,omitempty"`
+	Seed                            *uint32                          `json:"seed,omitempty"`
+	PronunciationDictionaryLocators []PronunciationDictionaryLocator `json:"pronunciation_dictionary_locators,omitempty"`
+	OutputFormat                    string                           `json:"-"`
+}
+
+// TextToDialogue renders a multi-speaker dialogue and returns the audio.
+func (c *Client) TextToDialogue(ctx context.Context, payload DialogueRequest) ([]byte, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/v1/text-to-dialogue")
+	if payload.OutputFormat != "" {
+		q := u.Query()
+		q.Set("output_format", payload.OutputFormat)
+		u.RawQuery = q.Encode()
+	}
+
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "audio/mpeg")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("text to dialogue failed: %s: %s", resp.Status, string(b))
+	}
+	return io.ReadAll(resp.Body)
+}
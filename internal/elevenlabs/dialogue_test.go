@@ -0,0 +1,36 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTextToDialogue(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-dialogue" || r.URL.Query().Get("output_format") != "mp3_44100_128" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		inputs, _ := body["inputs"].([]any)
+		if len(inputs) != 2 || body["model_id"] != "eleven_v3" || body["settings"] != nil {
+			t.Fatalf("unexpected body %v", body)
+		}
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer srv.Close()
+
+	data, err := NewClient("key", srv.URL).TextToDialogue(context.Background(), DialogueRequest{
+		Inputs:       []DialogueInput{{Text: "Hi", VoiceID: "a"}, {Text: "Hello", VoiceID: "b"}},
+		ModelID:      "eleven_v3",
+		OutputFormat: "mp3_44100_128",
+	})
+	if err != nil || string(data) != "audio" {
+		t.Fatalf("dialogue = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,177 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+// HistoryItem is one past generation kept by ElevenLabs.
+type HistoryItem struct {
+	HistoryItemID string         `json:"history_item_id"`
+	RequestID     string         `json:"request_id,omitempty"`
+	VoiceID       string         `json:"voice_id"`
+	VoiceName     string         `json:"voice_name"`
+	ModelID       string         `json:"model_id"`
+	Text          string         `json:"text"`
+	DateUnix      int64          `json:"date_unix"`
+	CharsFrom     int            `json:"character_count_change_from"`
+	CharsTo       int            `json:"character_count_change_to"`
+	ContentType   string         `json:"content_type"`
+	State         string         `json:"state"`
+	Source        string         `json:"source,omitempty"`
+	Settings      map[string]any `json:"settings,omitempty"`
+}
+
+// Date returns when the item was generated.
+func (h HistoryItem) Date() time.Time {
+	return time.Unix(h.DateUnix, 0)
+}
+
+// Characters returns how many characters the generation was billed for.
+func (h HistoryItem) Characters() int {
+	return h.CharsTo - h.CharsFrom
+}
+
+// HistoryQuery selects one page of history, newest first.
+type HistoryQuery struct {
+	VoiceID    string
+	PageSize   int
+	StartAfter string // history_item_id the page starts after
+}
+
+// HistoryPage is one page of history items.
+type HistoryPage struct {
+	Items   []HistoryItem `json:"history"`
+	LastID  string        `json:"last_history_item_id"`
+	HasMore bool          `json:"has_more"`
+}
+
+// ListHistory fetches one page of generation history.
+func (c *Client) ListHistory(ctx context.Context, q HistoryQuery) (HistoryPage, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return HistoryPage{}, err
+	}
+	u.Path = path.Join(u.Path, "/v1/history")
+	params := u.Query()
+	if q.PageSize > 0 {
+		params.Set("page_size", strconv.Itoa(q.PageSize))
+	}
+	if q.VoiceID != "" {
+		params.Set("voice_id", q.VoiceID)
+	}
+	if q.StartAfter != "" {
+		params.Set("start_after_history_item_id", q.StartAfter)
+	}
+	u.RawQuery = params.Encode()
+
+	var page HistoryPage
+	if err := c.getJSON(ctx, u.String(), "list history", &page); err != nil {
+		return HistoryPage{}, err
+	}
+	return page, nil
+}
+
+// GetHistoryItem fetches a single history item.
+func (c *Client) GetHistoryItem(ctx context.Context, id string) (HistoryItem, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return HistoryItem{}, err
+	}
+	u.Path = path.Join(u.Path, "/v1/history", id)
+
+	var item HistoryItem
+	if err := c.getJSON(ctx, u.String(), "get history item", &item); err != nil {
+		return HistoryItem{}, err
+	}
+	return item, nil
+}
+
+// HistoryAudio downloads the audio of a history item.
+func (c *Client) HistoryAudio(ctx context.Context, id string) ([]byte, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/v1/history", id, "audio")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("history audio failed: %s: %s", resp.Status, string(b))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// DeleteHistoryItem removes a history item and its audio.
+func (c *Client) DeleteHistoryItem(ctx context.Context, id string) error {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, "/v1/history", id)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete history item failed: %s: %s", resp.Status, string(b))
+	}
+	return nil
+}
+
+func (c *Client) getJSON(ctx context.Context, endpoint, action string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s failed: %s: %s", action, resp.Status, string(b))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,43 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListHistoryQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/history" || q.Get("voice_id") != "v1" || q.Get("page_size") != "50" || q.Get("start_after_history_item_id") != "h0" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"history":[{"history_item_id":"h1","voice_name":"Roger","date_unix":1760000000,"character_count_change_from":10,"character_count_change_to":22}],"last_history_item_id":"h1","has_more":true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	page, err := c.ListHistory(context.Background(), HistoryQuery{VoiceID: "v1", PageSize: 50, StartAfter: "h0"})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Items) != 1 || page.LastID != "h1" || !page.HasMore || page.Items[0].Characters() != 12 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+}
+
+func TestHistoryAudioError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/history/h1/audio" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	if _, err := c.HistoryAudio(context.Background(), "h1"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,53 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/url"
+	"path"
+)
+
+// Model describes an ElevenLabs model and what it supports.
+type Model struct {
+	ModelID              string          `json:"model_id"`
+	Name                 string          `json:"name"`
+	Description          string          `json:"description,omitempty"`
+	CanDoTextToSpeech    bool            `json:"can_do_text_to_speech"`
+	CanDoVoiceConversion bool            `json:"can_do_voice_conversion"`
+	CanUseStyle          bool            `json:"can_use_style"`
+	CanUseSpeakerBoost   bool            `json:"can_use_speaker_boost"`
+	MaxTextLengthRequest int             `json:"maximum_text_length_per_request"`
+	MaxCharsSubscribed   int             `json:"max_characters_request_subscribed_user"`
+	MaxCharsFree         int             `json:"max_characters_request_free_user"`
+	Languages            []ModelLanguage `json:"languages,omitempty"`
+	RequiresAlphaAccess  bool            `json:"requires_alpha_access,omitempty"`
+	TokenCostFactor      float64         `json:"token_cost_factor,omitempty"`
+}
+
+// ModelLanguage is a language a model can speak.
+type ModelLanguage struct {
+	LanguageID string `json:"language_id"`
+	Name       string `json:"name"`
+}
+
+// MaxChars returns the per-request character limit for a paid account (0 if unknown).
+func (m Model) MaxChars() int {
+	if m.MaxTextLengthRequest > 0 {
+		return m.MaxTextLengthRequest
+	}
+	return m.MaxCharsSubscribed
+}
+
+// ListModels fetches the available models.
+func (c *Client) ListModels(ctx context.Context) ([]Model, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/v1/models")
+
+	var models []Model
+	if err := c.getJSON(ctx, u.String(), "list models", &models); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
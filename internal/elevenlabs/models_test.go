@@ -0,0 +1,26 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListModels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"model_id":"eleven_flash_v2_5","can_do_text_to_speech":true,"can_use_speaker_boost":true,"max_characters_request_subscribed_user":40000,"languages":[{"language_id":"en","name":"English"}]}]`))
+	}))
+	defer srv.Close()
+
+	models, err := NewClient("key", srv.URL).ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(models) != 1 || models[0].MaxChars() != 40000 || !models[0].CanUseSpeakerBoost || models[0].Languages[0].LanguageID != "en" {
+		t.Fatalf("unexpected models %+v", models)
+	}
+}
@@ -0,0 +1,191 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+// MaxPronunciationDictionaries is how many dictionaries a single request may use.
+const MaxPronunciationDictionaries = 3
+
+// Pronunciation rule types and phoneme alphabets.
+const (
+	RuleAlias   = "alias"
+	RulePhoneme = "phoneme"
+	AlphabetIPA = "ipa"
+	AlphabetCMU = "cmu-arpabet"
+)
+
+const pronunciations = "/v1/pronunciation-dictionaries"
+
+// PronunciationRule replaces a word with an alias or a phoneme spelling.
+type PronunciationRule struct {
+	StringToReplace string `json:"string_to_replace"`
+	Type            string `json:"type"`
+	Alias           string `json:"alias,omitempty"`
+	Phoneme         string `json:"phoneme,omitempty"`
+	Alphabet        string `json:"alphabet,omitempty"`
+}
+
+// PronunciationDictionary is a stored pronunciation dictionary.
+type PronunciationDictionary struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	LatestVersionID  string `json:"latest_version_id"`
+	CreationTimeUnix int64  `json:"creation_time_unix,omitempty"`
+}
+
+// PronunciationDictionaryLocator selects a dictionary version for a TTS request.
+type PronunciationDictionaryLocator struct {
+	PronunciationDictionaryID string `json:"pronunciation_dictionary_id"`
+	VersionID                 string `json:"version_id,omitempty"`
+}
+
+// PronunciationDictionaryVersion identifies a dictionary version after a change.
+type PronunciationDictionaryVersion struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	VersionID string `json:"version_id"`
+}
+
+type listPronunciationDictionariesResponse struct {
+	Dictionaries []PronunciationDictionary `json:"pronunciation_dictionaries"`
+	NextCursor   *string                   `json:"next_cursor"`
+	HasMore      bool                      `json:"has_more"`
+}
+
+// ListPronunciationDictionaries fetches all pronunciation dictionaries.
+func (c *Client) ListPronunciationDictionaries(ctx context.Context) ([]PronunciationDictionary, error) {
+	var out []PronunciationDictionary
+	var cursor string
+	for {
+		u, err := url.Parse(c.baseURL)
+		if err != nil {
+			return nil, err
+		}
+		u.Path = path.Join(u.Path, pronunciations)
+		q := u.Query()
+		q.Set("page_size", strconv.Itoa(100))
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		u.RawQuery = q.Encode()
+
+		var body listPronunciationDictionariesResponse
+		if err := c.getJSON(ctx, u.String(), "list pronunciation dictionaries", &body); err != nil {
+			return nil, err
+		}
+		out = append(out, body.Dictionaries...)
+		if !body.HasMore || body.NextCursor == nil || *body.NextCursor == "" {
+			return out, nil
+		}
+		cursor = *body.NextCursor
+	}
+}
+
+// CreatePronunciationDictionary creates a dictionary from rules.
+func (c *Client) CreatePronunciationDictionary(ctx context.Context, name, description string, rules []PronunciationRule) (PronunciationDictionaryVersion, error) {
+	in := struct {
+		Name        string              `json:"name"`
+		Description string              `json:"description,omitempty"`
+		Rules       []PronunciationRule `json:"rules"`
+	}{name, description, rules}
+	var out PronunciationDictionaryVersion
+	if err := c.postJSON(ctx, path.Join(pronunciations, "add-from-rules"), in, &out); err != nil {
+		return PronunciationDictionaryVersion{}, fmt.Errorf("create pronunciation dictionary failed: %w", err)
+	}
+	return out, nil
+}
+
+// UploadPronunciationDictionary creates a dictionary from a PLS lexicon file.
+func (c *Client) UploadPronunciationDictionary(ctx context.Context, name, description, filename string, pls io.Reader) (PronunciationDictionaryVersion, error) {
+	if name == "" {
+		return PronunciationDictionaryVersion{}, errors.New("dictionary name is required")
+	}
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+	u.Path = path.Join(u.Path, pronunciations, "add-from-file")
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("name", name); err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+	if description != "" {
+		if err := mw.WriteField("description", description); err != nil {
+			return PronunciationDictionaryVersion{}, err
+		}
+	}
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+	if _, err := io.Copy(part, pls); err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+	if err := mw.Close(); err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), &buf)
+	if err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return PronunciationDictionaryVersion{}, fmt.Errorf("upload pronunciation dictionary failed: %s: %s", resp.Status, string(b))
+	}
+	var out PronunciationDictionaryVersion
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return PronunciationDictionaryVersion{}, err
+	}
+	return out, nil
+}
+
+// AddPronunciationRules adds or replaces rules and returns the new version.
+func (c *Client) AddPronunciationRules(ctx context.Context, dictionaryID string, rules []PronunciationRule) (PronunciationDictionaryVersion, error) {
+	in := struct {
+		Rules []PronunciationRule `json:"rules"`
+	}{rules}
+	var out PronunciationDictionaryVersion
+	if err := c.postJSON(ctx, path.Join(pronunciations, dictionaryID, "add-rules"), in, &out); err != nil {
+		return PronunciationDictionaryVersion{}, fmt.Errorf("add pronunciation rules failed: %w", err)
+	}
+	return out, nil
+}
+
+// RemovePronunciationRules removes the rules for the given words and returns the new version.
+func (c *Client) RemovePronunciationRules(ctx context.Context, dictionaryID string, words []string) (PronunciationDictionaryVersion, error) {
+	in := struct {
+		RuleStrings []string `json:"rule_strings"`
+	}{words}
+	var out PronunciationDictionaryVersion
+	if err := c.postJSON(ctx, path.Join(pronunciations, dictionaryID, "remove-rules"), in, &out); err != nil {
+		return PronunciationDictionaryVersion{}, fmt.Errorf("remove pronunciation rules failed: %w", err)
+	}
+	return out, nil
+}
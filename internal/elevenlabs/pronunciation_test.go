@@ -0,0 +1,79 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreatePronunciationDictionaryRules(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/pronunciation-dictionaries/add-from-rules" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		var body struct {
+			Name  string              `json:"name"`
+			Rules []PronunciationRule `json:"rules"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body.Name != "products" || len(body.Rules) != 2 || body.Rules[1].Alphabet != AlphabetIPA {
+			t.Fatalf("unexpected body %+v", body)
+		}
+		_, _ = w.Write([]byte(`{"id":"d1","name":"products","version_id":"v1"}`))
+	}))
+	defer srv.Close()
+
+	rules := []PronunciationRule{
+		{StringToReplace: "sag", Type: RuleAlias, Alias: "sagg"},
+		{StringToReplace: "tomato", Type: RulePhoneme, Phoneme: "təˈmeɪtoʊ", Alphabet: AlphabetIPA},
+	}
+	got, err := NewClient("key", srv.URL).CreatePronunciationDictionary(context.Background(), "products", "", rules)
+	if err != nil || got.ID != "d1" || got.VersionID != "v1" {
+		t.Fatalf("unexpected result %+v, %v", got, err)
+	}
+}
+
+func TestUploadPronunciationDictionary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		f, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("file: %v", err)
+		}
+		data, _ := io.ReadAll(f)
+		if r.FormValue("name") != "brands" || hdr.Filename != "brands.pls" || string(data) != "<lexicon/>" {
+			t.Fatalf("unexpected upload %q %q %q", r.FormValue("name"), hdr.Filename, data)
+		}
+		_, _ = w.Write([]byte(`{"id":"d2","version_id":"v1"}`))
+	}))
+	defer srv.Close()
+
+	got, err := NewClient("key", srv.URL).UploadPronunciationDictionary(context.Background(), "brands", "", "brands.pls", strings.NewReader("<lexicon/>"))
+	if err != nil || got.ID != "d2" {
+		t.Fatalf("unexpected result %+v, %v", got, err)
+	}
+}
+
+func TestListPronunciationDictionariesPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"pronunciation_dictionaries":[{"id":"d1","name":"a"}],"next_cursor":"c2","has_more":true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"pronunciation_dictionaries":[{"id":"d2","name":"b"}],"has_more":false}`))
+	}))
+	defer srv.Close()
+
+	dicts, err := NewClient("key", srv.URL).ListPronunciationDictionaries(context.Background())
+	if err != nil || len(dicts) != 2 || dicts[1].ID != "d2" {
+		t.Fatalf("unexpected dictionaries %+v, %v", dicts, err)
+	}
+}
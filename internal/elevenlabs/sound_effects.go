@@ -0,0 +1,61 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// SoundEffectRequest configures a sound-effect generation. Nil fields use the API defaults.
+type SoundEffectRequest struct {
+	Text            string   `json:"text"`
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	PromptInfluence *float64 `json:"prompt_influence,omitempty"`
+	Loop            bool     `json:"loop,omitempty"`
+	OutputFormat    string   `json:"-"`
+}
+
+// GenerateSoundEffect creates a sound effect from a text description and returns the audio.
+func (c *Client) GenerateSoundEffect(ctx context.Context, payload SoundEffectRequest) ([]byte, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/v1/sound-generation")
+	if payload.OutputFormat != "" {
+		q := u.Query()
+		q.Set("output_format", payload.OutputFormat)
+		u.RawQuery = q.Encode()
+	}
+
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "audio/mpeg")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("sound generation failed: %s: %s", resp.Status, string(b))
+	}
+	return io.ReadAll(resp.Body)
+}
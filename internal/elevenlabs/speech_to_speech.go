@@ -0,0 +1,118 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+// STSRequest configures a speech-to-speech (voice changer) request.
+type STSRequest struct {
+	ModelID               string
+	OutputFormat          string
+	VoiceSettings         *VoiceSettings
+	Seed                  *uint32
+	RemoveBackgroundNoise bool
+	// Latency is the optimize_streaming_latency tier; only used by StreamSTS.
+	Latency int
+}
+
+// ConvertSTS re-voices recorded audio and returns the full result.
+func (c *Client) ConvertSTS(ctx context.Context, voiceID, filename string, audio io.Reader, req STSRequest) ([]byte, error) {
+	resp, err := c.postSTS(ctx, path.Join("/v1/speech-to-speech", voiceID), filename, audio, req)
+	if err != nil {
+		return nil, fmt.Errorf("speech-to-speech failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return io.ReadAll(resp.Body)
+}
+
+// StreamSTS re-voices recorded audio and streams the result as it is generated.
+func (c *Client) StreamSTS(ctx context.Context, voiceID, filename string, audio io.Reader, req STSRequest) (io.ReadCloser, error) {
+	resp, err := c.postSTS(ctx, path.Join("/v1/speech-to-speech", voiceID, "stream"), filename, audio, req)
+	if err != nil {
+		return nil, fmt.Errorf("stream speech-to-speech failed: %w", err)
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) postSTS(ctx context.Context, endpoint, filename string, audio io.Reader, req STSRequest) (*http.Response, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, endpoint)
+	q := u.Query()
+	if req.OutputFormat != "" {
+		q.Set("output_format", req.OutputFormat)
+	}
+	if req.Latency > 0 {
+		q.Set("optimize_streaming_latency", fmt.Sprint(req.Latency))
+	}
+	u.RawQuery = q.Encode()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("audio", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	if req.ModelID != "" {
+		fields["model_id"] = req.ModelID
+	}
+	if req.VoiceSettings != nil {
+		settings, err := json.Marshal(req.VoiceSettings)
+		if err != nil {
+			return nil, err
+		}
+		fields["voice_settings"] = string(settings)
+	}
+	if req.Seed != nil {
+		fields["seed"] = strconv.FormatUint(uint64(*req.Seed), 10)
+	}
+	if req.RemoveBackgroundNoise {
+		fields["remove_background_noise"] = "true"
+	}
+	for key, value := range fields {
+		if err := mw.WriteField(key, value); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), &buf)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+	httpReq.Header.Set("Accept", "audio/mpeg")
+	httpReq.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, string(b))
+	}
+	return resp, nil
+}
@@ -0,0 +1,119 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+// STTRequest configures a speech-to-text request. Zero values use the API defaults.
+type STTRequest struct {
+	ModelID        string
+	LanguageCode   string
+	Diarize        bool
+	NumSpeakers    int
+	Timestamps     string // none, word, or character
+	TagAudioEvents *bool
+}
+
+// Transcript is the result of a speech-to-text request.
+type Transcript struct {
+	LanguageCode        string           `json:"language_code"`
+	LanguageProbability float64          `json:"language_probability"`
+	Text                string           `json:"text"`
+	Words               []TranscriptWord `json:"words"`
+}
+
+// TranscriptWord is a word, spacing, or audio event with its timing in seconds.
+type TranscriptWord struct {
+	Text       string                `json:"text"`
+	Start      float64               `json:"start"`
+	End        float64               `json:"end"`
+	Type       string                `json:"type"`
+	SpeakerID  string                `json:"speaker_id,omitempty"`
+	Characters []TranscriptCharacter `json:"characters,omitempty"`
+}
+
+// TranscriptCharacter is one character of a word, present with character timestamps.
+type TranscriptCharacter struct {
+	Text  string  `json:"text"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Transcribe converts recorded speech to text.
+func (c *Client) Transcribe(ctx context.Context, filename string, audio io.Reader, req STTRequest) (Transcript, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return Transcript{}, err
+	}
+	u.Path = path.Join(u.Path, "/v1/speech-to-text")
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return Transcript{}, err
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return Transcript{}, err
+	}
+	fields := map[string]string{"model_id": req.ModelID}
+	if req.LanguageCode != "" {
+		fields["language_code"] = req.LanguageCode
+	}
+	if req.Diarize {
+		fields["diarize"] = "true"
+	}
+	if req.NumSpeakers > 0 {
+		fields["num_speakers"] = strconv.Itoa(req.NumSpeakers)
+	}
+	if req.Timestamps != "" {
+		fields["timestamps_granularity"] = req.Timestamps
+	}
+	if req.TagAudioEvents != nil {
+		fields["tag_audio_events"] = strconv.FormatBool(*req.TagAudioEvents)
+	}
+	for key, value := range fields {
+		if err := mw.WriteField(key, value); err != nil {
+			return Transcript{}, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return Transcript{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), &buf)
+	if err != nil {
+		return Transcript{}, err
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("xi-api-key", c.apiKey)
+
+	// Long recordings can take a while to transcribe; only ctx bounds the request.
+	resp, err := (&http.Client{Transport: c.httpClient.Transport}).Do(httpReq)
+	if err != nil {
+		return Transcript{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return Transcript{}, fmt.Errorf("speech-to-text failed: %s: %s", resp.Status, string(b))
+	}
+	var transcript Transcript
+	if err := json.NewDecoder(resp.Body).Decode(&transcript); err != nil {
+		return Transcript{}, err
+	}
+	return transcript, nil
+}
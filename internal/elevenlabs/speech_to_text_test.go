@@ -0,0 +1,40 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTranscribeMultipart(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/speech-to-text" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if r.FormValue("model_id") != "scribe_v1" || r.FormValue("diarize") != "true" || r.FormValue("timestamps_granularity") != "word" {
+			t.Fatalf("unexpected fields %v", r.MultipartForm.Value)
+		}
+		if _, ok := r.MultipartForm.Value["tag_audio_events"]; ok {
+			t.Fatalf("unset tag_audio_events should be omitted")
+		}
+		if hdr := r.MultipartForm.File["file"][0]; hdr.Filename != "a.mp3" {
+			t.Fatalf("unexpected filename %q", hdr.Filename)
+		}
+		_, _ = w.Write([]byte(`{"language_code":"eng","text":"hi there","words":[{"text":"hi","start":0,"end":0.2,"type":"word","speaker_id":"speaker_0"}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	got, err := c.Transcribe(context.Background(), "a.mp3", strings.NewReader("ID3"), STTRequest{ModelID: "scribe_v1", Diarize: true, Timestamps: "word"})
+	if err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	if got.Text != "hi there" || len(got.Words) != 1 || got.Words[0].SpeakerID != "speaker_0" {
+		t.Fatalf("unexpected transcript %+v", got)
+	}
+}
@@ -0,0 +1,293 @@
+package elevenlabs
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/coder/websocket"
+)
+
+// StreamInputOptions configures a WebSocket input-streaming session.
+type StreamInputOptions struct {
+	ModelID       string
+	OutputFormat  string
+	LanguageCode  string
+	Latency       int
+	VoiceSettings *VoiceSettings
+	// ChunkLengthSchedule sets how many characters are buffered before each generation
+	// (e.g. 120,160,250,290). Empty uses the server default.
+	ChunkLengthSchedule []int
+	// PronunciationDictionaryLocators applies pronunciation dictionaries to the session.
+	PronunciationDictionaryLocators []PronunciationDictionaryLocator
+}
+
+type streamInputMessage struct {
+	Text                 string            `json:"text"`
+	TryTriggerGeneration bool              `json:"try_trigger_generation,omitempty"`
+	Flush                bool              `json:"flush,omitempty"`
+	VoiceSettings        *VoiceSettings    `json:"voice_settings,omitempty"`
+	GenerationConfig     *generationConfig `json:"generation_config,omitempty"`
+	// Locators are only sent with the first message.
+	PronunciationDictionaryLocators []PronunciationDictionaryLocator `json:"pronunciation_dictionary_locators,omitempty"`
+}
+
+type generationConfig struct {
+	ChunkLengthSchedule []int `json:"chunk_length_schedule,omitempty"`
+}
+
+type streamInputResponse struct {
+	Audio   string `json:"audio"`
+	IsFinal bool   `json:"isFinal"`
+	Message string `json:"message"`
+	Error   string `json:"error"`
+}
+
+// InputStream is an open stream-input session. Send text with SendText and Flush,
+// end input with CloseSend, and read the generated audio from Read.
+type InputStream struct {
+	conn  *websocket.Conn
+	audio *io.PipeReader
+	// pw feeds audio; closing it with an error surfaces that error to Read.
+	pw     *io.PipeWriter
+	cancel context.CancelFunc
+}
+
+// StreamInput opens /v1/text-to-speech/{voice_id}/stream-input so text can be sent
+// incrementally while audio is generated.
+func (c *Client) StreamInput(ctx context.Context, voiceID string, opts StreamInputOptions) (*InputStream, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	}
+	u.Path = path.Join(u.Path, "/v1/text-to-speech", voiceID, "stream-input")
+	q := u.Query()
+	if opts.ModelID != "" {
+		q.Set("model_id", opts.ModelID)
+	}
+	if opts.OutputFormat != "" {
+		q.Set("output_format", opts.OutputFormat)
+	}
+	if opts.LanguageCode != "" {
+		q.Set("language_code", opts.LanguageCode)
+	}
+	if opts.Latency > 0 {
+		q.Set("optimize_streaming_latency", fmt.Sprint(opts.Latency))
+	}
+	u.RawQuery = q.Encode()
+
+	ctx, cancel := context.WithCancel(ctx)
+	header := http.Header{}
+	header.Set("xi-api-key", c.apiKey)
+	conn, resp, err := websocket.Dial(ctx, u.String(), &websocket.DialOptions{HTTPHeader: header})
+	if err != nil {
+		cancel()
+		if resp != nil && resp.StatusCode >= 400 {
+			return nil, fmt.Errorf("stream input failed: %s", resp.Status)
+		}
+		return nil, fmt.Errorf("stream input failed: %w", err)
+	}
+	conn.SetReadLimit(-1)
+
+	// The first message opens the session; its text must be a single space.
+	first := streamInputMessage{Text: " ", VoiceSettings: opts.VoiceSettings, PronunciationDictionaryLocators: opts.PronunciationDictionaryLocators}
+	if len(opts.ChunkLengthSchedule) > 0 {
+		first.GenerationConfig = &generationConfig{ChunkLengthSchedule: opts.ChunkLengthSchedule}
+	}
+	if err := writeJSON(ctx, conn, first); err != nil {
+		cancel()
+		_ = conn.CloseNow()
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer cancel()
+		_ = pw.CloseWithError(readStreamInput(ctx, conn, pw))
+	}()
+
+	return &InputStream{conn: conn, audio: pr, pw: pw, cancel: cancel}, nil
+}
+
+// SendText queues text for synthesis. With tryTrigger the server is asked to
+// start generating as soon as enough text is buffered.
+func (s *InputStream) SendText(ctx context.Context, text string, tryTrigger bool) error {
+	if text == "" {
+		return nil
+	}
+	if !strings.HasSuffix(text, " ") {
+		// The protocol expects every chunk to end with a space.
+		text += " "
+	}
+	return writeJSON(ctx, s.conn, streamInputMessage{Text: text, TryTriggerGeneration: tryTrigger})
+}
+
+// Flush forces generation of any buffered text.
+func (s *InputStream) Flush(ctx context.Context) error {
+	return writeJSON(ctx, s.conn, streamInputMessage{Text: " ", Flush: true})
+}
+
+// CloseSend signals end of input; the server finishes generating and closes the stream.
+func (s *InputStream) CloseSend(ctx context.Context) error {
+	return writeJSON(ctx, s.conn, streamInputMessage{Text: ""})
+}
+
+// Read returns generated audio as it arrives.
+func (s *InputStream) Read(p []byte) (int, error) {
+	return s.audio.Read(p)
+}
+
+// Close tears down the session.
+func (s *InputStream) Close() error {
+	s.cancel()
+	_ = s.audio.Close()
+	return s.conn.CloseNow()
+}
+
+// StreamTTSInput streams text from r over a stream-input session and returns the audio.
+// Text is sent at word boundaries as it arrives, so speech can start before r is exhausted.
+func (c *Client) StreamTTSInput(ctx context.Context, voiceID string, r io.Reader, opts StreamInputOptions) (io.ReadCloser, error) {
+	stream, err := c.StreamInput(ctx, voiceID, opts)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		if err := pumpText(ctx, stream, r); err != nil {
+			// The pipe keeps the first error, so the read loop's close error does not mask it.
+			_ = stream.pw.CloseWithError(err)
+			_ = stream.conn.CloseNow()
+		}
+	}()
+	return stream, nil
+}
+
+func pumpText(ctx context.Context, stream *InputStream, r io.Reader) error {
+	br := bufio.NewReader(r)
+	buf := make([]byte, 4096)
+	var pending strings.Builder
+	for {
+		n, err := br.Read(buf)
+		if n > 0 {
+			pending.Write(buf[:n])
+			text := pending.String()
+			if cut := chunkBoundary(text); cut > 0 {
+				if sendErr := stream.SendText(ctx, text[:cut], true); sendErr != nil {
+					return sendErr
+				}
+				pending.Reset()
+				pending.WriteString(text[cut:])
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if rest := strings.TrimSpace(pending.String()); rest != "" {
+		if err := stream.SendText(ctx, rest, true); err != nil {
+			return err
+		}
+	}
+	if err := stream.Flush(ctx); err != nil {
+		return err
+	}
+	return stream.CloseSend(ctx)
+}
+
+// maxPendingText is how many bytes of unbroken text are held back before they are sent anyway.
+const maxPendingText = 150
+
+// chunkBoundary returns where pending text can be sent: after the last whitespace, else after the
+// last non-ASCII punctuation mark (CJK text has no spaces), else at a rune boundary once the text
+// reaches maxPendingText. It returns 0 to keep waiting.
+func chunkBoundary(s string) int {
+	if cut := lastWordBoundary(s); cut > 0 {
+		return cut
+	}
+	if idx := strings.LastIndexFunc(s, func(r rune) bool { return r > unicode.MaxASCII && unicode.IsPunct(r) }); idx >= 0 {
+		_, size := utf8.DecodeRuneInString(s[idx:])
+		return idx + size
+	}
+	if len(s) < maxPendingText {
+		return 0
+	}
+	// Keep a rune split across reads for the next chunk.
+	cut := len(s)
+	for i := len(s) - 1; i >= 0 && i >= len(s)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			if !utf8.FullRuneInString(s[i:]) {
+				cut = i
+			}
+			break
+		}
+	}
+	return cut
+}
+
+// lastWordBoundary returns the index just past the last whitespace rune in s, or 0.
+func lastWordBoundary(s string) int {
+	idx := strings.LastIndexFunc(s, unicode.IsSpace)
+	if idx < 0 {
+		return 0
+	}
+	_, size := utf8.DecodeRuneInString(s[idx:])
+	return idx + size
+}
+
+func readStreamInput(ctx context.Context, conn *websocket.Conn, w io.Writer) error {
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			if websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+				return nil
+			}
+			return fmt.Errorf("stream input: %w", err)
+		}
+		var msg streamInputResponse
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return fmt.Errorf("stream input: decode message: %w", err)
+		}
+		if msg.Error != "" || (msg.Message != "" && msg.Audio == "" && !msg.IsFinal) {
+			return fmt.Errorf("stream input failed: %s", strings.TrimSpace(msg.Error+" "+msg.Message))
+		}
+		if msg.Audio != "" {
+			chunk, err := base64.StdEncoding.DecodeString(msg.Audio)
+			if err != nil {
+				return fmt.Errorf("stream input: decode audio: %w", err)
+			}
+			if _, err := w.Write(chunk); err != nil {
+				return err
+			}
+		}
+		if msg.IsFinal {
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+			return nil
+		}
+	}
+}
+
+func writeJSON(ctx context.Context, conn *websocket.Conn, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return conn.Write(ctx, websocket.MessageText, data)
+}
@@ -0,0 +1,194 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/coder/websocket"
+)
+
+func TestStreamTTSInputSendsChunksAndReturnsAudio(t *testing.T) {
+	var got []streamInputMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-speech/voice123/stream-input" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("xi-api-key") != "key" {
+			t.Errorf("missing api key header")
+		}
+		if r.URL.Query().Get("model_id") != "eleven_flash_v2_5" || r.URL.Query().Get("output_format") != "mp3_44100_128" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Errorf("accept: %v", err)
+			return
+		}
+		defer func() { _ = conn.CloseNow() }()
+		ctx := r.Context()
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			var msg streamInputMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Errorf("decode: %v", err)
+				return
+			}
+			got = append(got, msg)
+			if msg.TryTriggerGeneration {
+				audio := base64.StdEncoding.EncodeToString([]byte("[" + strings.TrimSpace(msg.Text) + "]"))
+				_ = conn.Write(ctx, websocket.MessageText, []byte(`{"audio":"`+audio+`","isFinal":false}`))
+			}
+			if msg.Text == "" {
+				_ = conn.Write(ctx, websocket.MessageText, []byte(`{"audio":null,"isFinal":true}`))
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("Hello wor"))
+		_, _ = pw.Write([]byte("ld from sag"))
+		_ = pw.Close()
+	}()
+
+	c := NewClient("key", srv.URL)
+	audio, err := c.StreamTTSInput(context.Background(), "voice123", pr, StreamInputOptions{
+		ModelID:             "eleven_flash_v2_5",
+		OutputFormat:        "mp3_44100_128",
+		ChunkLengthSchedule: []int{50, 120},
+	})
+	if err != nil {
+		t.Fatalf("StreamTTSInput error: %v", err)
+	}
+	defer func() { _ = audio.Close() }()
+
+	data, err := io.ReadAll(audio)
+	if err != nil {
+		t.Fatalf("read audio: %v", err)
+	}
+	if string(data) != "[Hello][world from][sag]" {
+		t.Fatalf("unexpected audio: %q", string(data))
+	}
+
+	if len(got) < 5 {
+		t.Fatalf("expected init, text, flush and close messages, got %+v", got)
+	}
+	first := got[0]
+	if first.Text != " " || first.GenerationConfig == nil || len(first.GenerationConfig.ChunkLengthSchedule) != 2 {
+		t.Fatalf("unexpected init message: %+v", first)
+	}
+	flush := got[len(got)-2]
+	if !flush.Flush {
+		t.Fatalf("expected flush before close, got %+v", flush)
+	}
+	if last := got[len(got)-1]; last.Text != "" || last.Flush {
+		t.Fatalf("expected end-of-input message, got %+v", last)
+	}
+}
+
+func TestStreamTTSInputSurfacesTextReadError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Errorf("accept: %v", err)
+			return
+		}
+		defer func() { _ = conn.CloseNow() }()
+		for {
+			if _, _, err := conn.Read(r.Context()); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	readErr := errors.New("stdin went away")
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("Hello "))
+		_ = pw.CloseWithError(readErr)
+	}()
+
+	audio, err := NewClient("key", srv.URL).StreamTTSInput(context.Background(), "voice123", pr, StreamInputOptions{})
+	if err != nil {
+		t.Fatalf("StreamTTSInput error: %v", err)
+	}
+	defer func() { _ = audio.Close() }()
+
+	if _, err := io.ReadAll(audio); !errors.Is(err, readErr) {
+		t.Fatalf("Read error = %v, want %v", err, readErr)
+	}
+}
+
+func TestStreamInputSurfacesServerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.CloseNow() }()
+		_, _, _ = conn.Read(r.Context())
+		_ = conn.Write(r.Context(), websocket.MessageText, []byte(`{"message":"invalid api key","error":"unauthorized"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("bad", srv.URL)
+	stream, err := c.StreamInput(context.Background(), "v", StreamInputOptions{})
+	if err != nil {
+		t.Fatalf("StreamInput error: %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+	_, err = io.ReadAll(stream)
+	if err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Fatalf("expected server error, got %v", err)
+	}
+}
+
+func TestLastWordBoundary(t *testing.T) {
+	if got := lastWordBoundary("hello wor"); got != 6 {
+		t.Fatalf("lastWordBoundary = %d, want 6", got)
+	}
+	if got := lastWordBoundary("hello"); got != 0 {
+		t.Fatalf("lastWordBoundary without space = %d, want 0", got)
+	}
+}
+
+func TestLastWordBoundaryMultibyteSpace(t *testing.T) {
+	for _, s := range []string{"hello wor", "你好　世界"} {
+		cut := lastWordBoundary(s)
+		if !utf8.ValidString(s[:cut]) || !utf8.ValidString(s[cut:]) || strings.TrimSpace(s[:cut]) == s[:cut] {
+			t.Fatalf("lastWordBoundary(%q) = %d splits the space", s, cut)
+		}
+	}
+}
+
+func TestChunkBoundaryWithoutSpaces(t *testing.T) {
+	if got := chunkBoundary("你好。世界"); got != len("你好。") {
+		t.Fatalf("chunkBoundary at CJK full stop = %d, want %d", got, len("你好。"))
+	}
+	if got := chunkBoundary("你好世界"); got != 0 {
+		t.Fatalf("short unbroken text should wait, got %d", got)
+	}
+	long := strings.Repeat("世", 60)
+	// A read that ends inside a rune keeps the partial rune for the next chunk.
+	partial := long + "界"[:2]
+	if got := chunkBoundary(partial); got != len(long) {
+		t.Fatalf("chunkBoundary = %d, want %d", got, len(long))
+	}
+	if got := chunkBoundary(long); got != len(long) {
+		t.Fatalf("chunkBoundary = %d, want %d", got, len(long))
+	}
+}
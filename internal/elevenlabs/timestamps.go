@@ -0,0 +1,163 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// Alignment holds per-character timings in seconds.
+type Alignment struct {
+	Characters                 []string  `json:"characters"`
+	CharacterStartTimesSeconds []float64 `json:"character_start_times_seconds"`
+	CharacterEndTimesSeconds   []float64 `json:"character_end_times_seconds"`
+}
+
+func (a *Alignment) append(b *Alignment) {
+	if b == nil {
+		return
+	}
+	a.Characters = append(a.Characters, b.Characters...)
+	a.CharacterStartTimesSeconds = append(a.CharacterStartTimesSeconds, b.CharacterStartTimesSeconds...)
+	a.CharacterEndTimesSeconds = append(a.CharacterEndTimesSeconds, b.CharacterEndTimesSeconds...)
+}
+
+// TimestampedAudio is audio plus the alignment of the original and normalized text.
+type TimestampedAudio struct {
+	Audio               []byte
+	Alignment           Alignment
+	NormalizedAlignment Alignment
+}
+
+type timestampsResponse struct {
+	AudioBase64         string     `json:"audio_base64"`
+	Alignment           *Alignment `json:"alignment"`
+	NormalizedAlignment *Alignment `json:"normalized_alignment"`
+}
+
+// ConvertTTSWithTimestamps downloads the full audio with character timings.
+func (c *Client) ConvertTTSWithTimestamps(ctx context.Context, voiceID string, payload TTSRequest) (TimestampedAudio, error) {
+	resp, err := c.postTimestamps(ctx, path.Join("/v1/text-to-speech", voiceID, "with-timestamps"), payload, 0)
+	if err != nil {
+		return TimestampedAudio{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var body timestampsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return TimestampedAudio{}, err
+	}
+	audio, err := base64.StdEncoding.DecodeString(body.AudioBase64)
+	if err != nil {
+		return TimestampedAudio{}, fmt.Errorf("decode audio: %w", err)
+	}
+	out := TimestampedAudio{Audio: audio}
+	out.Alignment.append(body.Alignment)
+	out.NormalizedAlignment.append(body.NormalizedAlignment)
+	return out, nil
+}
+
+// TimestampStream yields decoded audio from a streaming with-timestamps response
+// and collects the alignment as chunks arrive.
+type TimestampStream struct {
+	body       io.ReadCloser
+	dec        *json.Decoder
+	pending    []byte
+	alignment  Alignment
+	normalized Alignment
+}
+
+// StreamTTSWithTimestamps streams audio with character timings.
+func (c *Client) StreamTTSWithTimestamps(ctx context.Context, voiceID string, payload TTSRequest, latency int) (*TimestampStream, error) {
+	resp, err := c.postTimestamps(ctx, path.Join("/v1/text-to-speech", voiceID, "stream", "with-timestamps"), payload, latency)
+	if err != nil {
+		return nil, err
+	}
+	return &TimestampStream{body: resp.Body, dec: json.NewDecoder(resp.Body)}, nil
+}
+
+// Read returns decoded audio bytes.
+func (s *TimestampStream) Read(p []byte) (int, error) {
+	for len(s.pending) == 0 {
+		var chunk timestampsResponse
+		if err := s.dec.Decode(&chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, io.EOF
+			}
+			return 0, fmt.Errorf("decode timestamps stream: %w", err)
+		}
+		audio, err := base64.StdEncoding.DecodeString(chunk.AudioBase64)
+		if err != nil {
+			return 0, fmt.Errorf("decode audio: %w", err)
+		}
+		s.pending = audio
+		s.alignment.append(chunk.Alignment)
+		s.normalized.append(chunk.NormalizedAlignment)
+	}
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// Close closes the underlying response body.
+func (s *TimestampStream) Close() error {
+	return s.body.Close()
+}
+
+// Alignment returns the character timings received so far; complete once Read returns io.EOF.
+func (s *TimestampStream) Alignment() Alignment {
+	return s.alignment
+}
+
+// NormalizedAlignment returns the normalized-text timings received so far.
+func (s *TimestampStream) NormalizedAlignment() Alignment {
+	return s.normalized
+}
+
+func (c *Client) postTimestamps(ctx context.Context, endpoint string, payload TTSRequest, latency int) (*http.Response, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, endpoint)
+	if latency > 0 {
+		q := u.Query()
+		q.Set("optimize_streaming_latency", fmt.Sprint(latency))
+		u.RawQuery = q.Encode()
+	}
+
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("TTS with timestamps failed: %s: %s", resp.Status, string(b))
+	}
+	return resp, nil
+}
@@ -0,0 +1,80 @@
+package elevenlabs
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConvertTTSWithTimestamps(t *testing.T) {
+	audio := base64.StdEncoding.EncodeToString([]byte("abc"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-speech/v1/with-timestamps" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"audio_base64":"` + audio + `","alignment":{"characters":["h","i"],"character_start_times_seconds":[0,0.1],"character_end_times_seconds":[0.1,0.2]}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	got, err := c.ConvertTTSWithTimestamps(context.Background(), "v1", TTSRequest{Text: "hi"})
+	if err != nil {
+		t.Fatalf("ConvertTTSWithTimestamps error: %v", err)
+	}
+	if string(got.Audio) != "abc" {
+		t.Fatalf("unexpected audio: %q", string(got.Audio))
+	}
+	if len(got.Alignment.Characters) != 2 || got.Alignment.CharacterEndTimesSeconds[1] != 0.2 {
+		t.Fatalf("unexpected alignment: %+v", got.Alignment)
+	}
+}
+
+func TestStreamTTSWithTimestampsCollectsAlignment(t *testing.T) {
+	first := base64.StdEncoding.EncodeToString([]byte("one"))
+	second := base64.StdEncoding.EncodeToString([]byte("two"))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/text-to-speech/v1/stream/with-timestamps" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("optimize_streaming_latency") != "2" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"audio_base64":"` + first + `","alignment":{"characters":["a"],"character_start_times_seconds":[0],"character_end_times_seconds":[0.1]}}` + "\n"))
+		_, _ = w.Write([]byte(`{"audio_base64":"` + second + `","alignment":null}` + "\n"))
+		_, _ = w.Write([]byte(`{"audio_base64":"","alignment":{"characters":["b"],"character_start_times_seconds":[0.1],"character_end_times_seconds":[0.2]}}` + "\n"))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	stream, err := c.StreamTTSWithTimestamps(context.Background(), "v1", TTSRequest{Text: "ab"}, 2)
+	if err != nil {
+		t.Fatalf("StreamTTSWithTimestamps error: %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if string(data) != "onetwo" {
+		t.Fatalf("unexpected audio: %q", string(data))
+	}
+	if got := stream.Alignment().Characters; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("unexpected alignment: %+v", stream.Alignment())
+	}
+}
+
+func TestTimestampsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad voice", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	if _, err := c.ConvertTTSWithTimestamps(context.Background(), "v1", TTSRequest{Text: "hi"}); err == nil {
+		t.Fatalf("expected error")
+	}
+}
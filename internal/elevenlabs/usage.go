@@ -0,0 +1,90 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+// Subscription describes the account's plan and character quota.
+type Subscription struct {
+	Tier                        string `json:"tier"`
+	Status                      string `json:"status"`
+	CharacterCount              int    `json:"character_count"`
+	CharacterLimit              int    `json:"character_limit"`
+	CanExtendCharacterLimit     bool   `json:"can_extend_character_limit"`
+	NextCharacterCountResetUnix int64  `json:"next_character_count_reset_unix"`
+	VoiceSlotsUsed              int    `json:"voice_slots_used"`
+	VoiceLimit                  int    `json:"voice_limit"`
+	ProfessionalVoiceLimit      int    `json:"professional_voice_limit"`
+}
+
+// Remaining returns the characters left until the next reset.
+func (s Subscription) Remaining() int {
+	return max(s.CharacterLimit-s.CharacterCount, 0)
+}
+
+// ResetsAt returns when the character count resets, or the zero time if unknown.
+func (s Subscription) ResetsAt() time.Time {
+	if s.NextCharacterCountResetUnix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(s.NextCharacterCountResetUnix, 0)
+}
+
+// DailyUsage is the characters used on one day.
+type DailyUsage struct {
+	Date       time.Time `json:"date"`
+	Characters int       `json:"characters"`
+}
+
+type characterStatsResponse struct {
+	Time  []int64              `json:"time"`
+	Usage map[string][]float64 `json:"usage"`
+}
+
+// GetSubscription fetches the account's subscription and quota.
+func (c *Client) GetSubscription(ctx context.Context) (Subscription, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return Subscription{}, err
+	}
+	u.Path = path.Join(u.Path, "/v1/user/subscription")
+
+	var sub Subscription
+	if err := c.getJSON(ctx, u.String(), "get subscription", &sub); err != nil {
+		return Subscription{}, err
+	}
+	return sub, nil
+}
+
+// CharacterUsage returns characters used per day in [start, end).
+func (c *Client) CharacterUsage(ctx context.Context, start, end time.Time) ([]DailyUsage, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/v1/usage/character-stats")
+	q := u.Query()
+	q.Set("start_unix", strconv.FormatInt(start.UnixMilli(), 10))
+	q.Set("end_unix", strconv.FormatInt(end.UnixMilli(), 10))
+	q.Set("aggregation_interval", "day")
+	u.RawQuery = q.Encode()
+
+	var body characterStatsResponse
+	if err := c.getJSON(ctx, u.String(), "character usage", &body); err != nil {
+		return nil, err
+	}
+	days := make([]DailyUsage, len(body.Time))
+	for i, ms := range body.Time {
+		days[i].Date = time.UnixMilli(ms)
+		for _, series := range body.Usage {
+			if i < len(series) {
+				days[i].Characters += int(series[i])
+			}
+		}
+	}
+	return days, nil
+}
@@ -0,0 +1,36 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCharacterUsageSumsBreakdowns(t *testing.T) {
+	start := time.UnixMilli(1760000000000)
+	end := start.Add(48 * time.Hour)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/usage/character-stats" || q.Get("start_unix") != "1760000000000" || q.Get("aggregation_interval") != "day" {
+			t.Fatalf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"time":[1760000000000,1760086400000],"usage":{"a":[10,20],"b":[1,2]}}`))
+	}))
+	defer srv.Close()
+
+	days, err := NewClient("key", srv.URL).CharacterUsage(context.Background(), start, end)
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if len(days) != 2 || days[0].Characters != 11 || days[1].Characters != 22 || !days[1].Date.Equal(time.UnixMilli(1760086400000)) {
+		t.Fatalf("unexpected usage %+v", days)
+	}
+}
+
+func TestSubscriptionRemaining(t *testing.T) {
+	if got := (Subscription{CharacterCount: 120, CharacterLimit: 100}).Remaining(); got != 0 {
+		t.Fatalf("expected 0 remaining when over the limit, got %d", got)
+	}
+}
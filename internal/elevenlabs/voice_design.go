@@ -0,0 +1,123 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// VoiceDesignRequest describes a voice to generate. Text is the preview script (100-1000 chars);
+// leave it empty to let ElevenLabs write one.
+type VoiceDesignRequest struct {
+	Description string `json:"voice_description"`
+	Text        string `json:"text,omitempty"`
+	AutoText    bool   `json:"auto_generate_text,omitempty"`
+	ModelID     string `json:"model_id,omitempty"`
+}
+
+// VoicePreview is one generated candidate. GeneratedVoiceID is passed to CreateVoiceFromPreview to keep it.
+type VoicePreview struct {
+	GeneratedVoiceID string
+	Audio            []byte
+	MediaType        string
+	DurationSecs     float64
+}
+
+type voiceDesignResponse struct {
+	Previews []struct {
+		AudioBase64      string  `json:"audio_base_64"`
+		GeneratedVoiceID string  `json:"generated_voice_id"`
+		MediaType        string  `json:"media_type"`
+		DurationSecs     float64 `json:"duration_secs"`
+	} `json:"previews"`
+	Text string `json:"text"`
+}
+
+// DesignVoice generates candidate voices from a text description. It returns the previews and the spoken text.
+func (c *Client) DesignVoice(ctx context.Context, req VoiceDesignRequest) ([]VoicePreview, string, error) {
+	if req.Text == "" {
+		req.AutoText = true
+	}
+	var body voiceDesignResponse
+	if err := c.postJSON(ctx, "/v1/text-to-voice/design", req, &body); err != nil {
+		return nil, "", fmt.Errorf("design voice failed: %w", err)
+	}
+	previews := make([]VoicePreview, 0, len(body.Previews))
+	for _, p := range body.Previews {
+		audio, err := base64.StdEncoding.DecodeString(p.AudioBase64)
+		if err != nil {
+			return nil, "", fmt.Errorf("decode preview %s: %w", p.GeneratedVoiceID, err)
+		}
+		previews = append(previews, VoicePreview{
+			GeneratedVoiceID: p.GeneratedVoiceID,
+			Audio:            audio,
+			MediaType:        p.MediaType,
+			DurationSecs:     p.DurationSecs,
+		})
+	}
+	if len(previews) == 0 {
+		return nil, "", errors.New("design voice returned no previews")
+	}
+	return previews, body.Text, nil
+}
+
+type createVoiceFromPreviewRequest struct {
+	Name             string            `json:"voice_name"`
+	Description      string            `json:"voice_description"`
+	GeneratedVoiceID string            `json:"generated_voice_id"`
+	Labels           map[string]string `json:"labels,omitempty"`
+}
+
+// CreateVoiceFromPreview saves a designed preview as a permanent voice in the account.
+func (c *Client) CreateVoiceFromPreview(ctx context.Context, name, description, generatedVoiceID string, labels map[string]string) (Voice, error) {
+	var voice Voice
+	req := createVoiceFromPreviewRequest{Name: name, Description: description, GeneratedVoiceID: generatedVoiceID, Labels: labels}
+	if err := c.postJSON(ctx, "/v1/text-to-voice", req, &voice); err != nil {
+		return Voice{}, fmt.Errorf("create voice failed: %w", err)
+	}
+	if voice.VoiceID == "" {
+		return Voice{}, errors.New("create voice response missing voice_id")
+	}
+	return voice, nil
+}
+
+func (c *Client) postJSON(ctx context.Context, endpoint string, in, out any) error {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, endpoint)
+
+	bodyBytes, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, string(b))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
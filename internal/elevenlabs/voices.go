@@ -0,0 +1,163 @@
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+// VoiceSample is an audio file uploaded to clone a voice.
+type VoiceSample struct {
+	Filename string
+	Data     io.Reader
+}
+
+// VoiceParams describes a cloned voice. EditVoice replaces description and labels with the given values.
+type VoiceParams struct {
+	Name                  string
+	Description           string
+	Labels                map[string]string
+	Samples               []VoiceSample
+	RemoveBackgroundNoise bool
+}
+
+type addVoiceResponse struct {
+	VoiceID string `json:"voice_id"`
+}
+
+// AddVoice creates an instant voice clone from samples and returns its voice ID.
+func (c *Client) AddVoice(ctx context.Context, params VoiceParams) (string, error) {
+	if len(params.Samples) == 0 {
+		return "", errors.New("add voice requires at least one sample")
+	}
+	resp, err := c.postVoiceForm(ctx, "/v1/voices/add", params)
+	if err != nil {
+		return "", fmt.Errorf("add voice failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var body addVoiceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.VoiceID == "" {
+		return "", errors.New("add voice response missing voice_id")
+	}
+	return body.VoiceID, nil
+}
+
+// EditVoice updates a voice's name, description, and labels, and appends any samples.
+func (c *Client) EditVoice(ctx context.Context, voiceID string, params VoiceParams) error {
+	resp, err := c.postVoiceForm(ctx, path.Join("/v1/voices", voiceID, "edit"), params)
+	if err != nil {
+		return fmt.Errorf("edit voice failed: %w", err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// DeleteVoice permanently removes a voice from the account.
+func (c *Client) DeleteVoice(ctx context.Context, voiceID string) error {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, "/v1/voices", voiceID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete voice failed: %s: %s", resp.Status, string(b))
+	}
+	return nil
+}
+
+func (c *Client) postVoiceForm(ctx context.Context, endpoint string, params VoiceParams) (*http.Response, error) {
+	if params.Name == "" {
+		return nil, errors.New("voice name is required")
+	}
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, endpoint)
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fields := map[string]string{"name": params.Name}
+	if params.Description != "" {
+		fields["description"] = params.Description
+	}
+	if params.Labels != nil {
+		labels, err := json.Marshal(params.Labels)
+		if err != nil {
+			return nil, err
+		}
+		fields["labels"] = string(labels)
+	}
+	if params.RemoveBackgroundNoise {
+		fields["remove_background_noise"] = strconv.FormatBool(true)
+	}
+	for key, value := range fields {
+		if err := mw.WriteField(key, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, sample := range params.Samples {
+		part, err := mw.CreateFormFile("files", sample.Filename)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(part, sample.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("xi-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, string(b))
+	}
+	return resp, nil
+}
@@ -0,0 +1,65 @@
+package elevenlabs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAddVoiceRequiresSamples(t *testing.T) {
+	c := NewClient("key", "http://invalid")
+	if _, err := c.AddVoice(context.Background(), VoiceParams{Name: "x"}); err == nil {
+		t.Fatal("expected error without samples")
+	}
+}
+
+func TestAddVoiceMultipart(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("xi-api-key") != "key" {
+			t.Fatalf("missing api key header")
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if r.FormValue("remove_background_noise") != "true" || r.FormValue("description") != "gruff" {
+			t.Fatalf("unexpected fields %v", r.MultipartForm.Value)
+		}
+		if _, ok := r.MultipartForm.Value["labels"]; ok {
+			t.Fatalf("nil labels should be omitted")
+		}
+		hdr := r.MultipartForm.File["files"][0]
+		if hdr.Filename != "a.mp3" {
+			t.Fatalf("unexpected filename %q", hdr.Filename)
+		}
+		_, _ = w.Write([]byte(`{"voice_id":"v1"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL)
+	id, err := c.AddVoice(context.Background(), VoiceParams{
+		Name:                  "Captain",
+		Description:           "gruff",
+		Samples:               []VoiceSample{{Filename: "a.mp3", Data: strings.NewReader("audio")}},
+		RemoveBackgroundNoise: true,
+	})
+	if err != nil || id != "v1" {
+		t.Fatalf("AddVoice = %q, %v", id, err)
+	}
+}
+
+func TestDeleteVoiceError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/v1/voices/v1" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		http.Error(w, `{"detail":"not found"}`, http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	err := NewClient("key", srv.URL).DeleteVoice(context.Background(), "v1")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
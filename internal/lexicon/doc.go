@@ -0,0 +1,2 @@
+// Package lexicon loads local pronunciation rules (YAML or PLS) and applies them to text before synthesis.
+package lexicon
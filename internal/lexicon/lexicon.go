@@ -0,0 +1,201 @@
+package lexicon
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule rewrites matching text. Literal rules match whole words case-insensitively unless configured otherwise;
+// regex rules use RE2 syntax and may refer to groups ($1) in Replace.
+type Rule struct {
+	Match         string `yaml:"match"`
+	Replace       string `yaml:"replace"`
+	CaseSensitive bool   `yaml:"case_sensitive"`
+	WholeWord     *bool  `yaml:"whole_word"`
+	Regex         bool   `yaml:"regex"`
+
+	re *regexp.Regexp
+}
+
+// Lexicon is an ordered list of rules; earlier rules are applied first.
+type Lexicon struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Replacement is one distinct piece of text a rule matched, with what it should be spoken as.
+type Replacement struct {
+	Text    string
+	Replace string
+}
+
+// Load reads a lexicon from a .yaml/.yml or .pls/.xml file.
+func Load(path string) (*Lexicon, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lex *Lexicon
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pls", ".xml":
+		lex, err = ParsePLS(data)
+	case ".yaml", ".yml":
+		lex, err = ParseYAML(data)
+	default:
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+			lex, err = ParsePLS(data)
+		} else {
+			lex, err = ParseYAML(data)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return lex, nil
+}
+
+// ParseYAML parses a lexicon of the form "rules: [{match, replace, case_sensitive, whole_word, regex}]".
+func ParseYAML(data []byte) (*Lexicon, error) {
+	var lex Lexicon
+	if err := yaml.Unmarshal(data, &lex); err != nil {
+		return nil, err
+	}
+	if err := lex.compile(); err != nil {
+		return nil, err
+	}
+	return &lex, nil
+}
+
+type plsLexicon struct {
+	Lexemes []struct {
+		Graphemes []string `xml:"grapheme"`
+		Aliases   []string `xml:"alias"`
+	} `xml:"lexeme"`
+}
+
+// ParsePLS reads the alias entries of a W3C Pronunciation Lexicon. Graphemes match case-sensitively as whole words.
+// Phoneme-only lexemes cannot be expressed as text and are skipped.
+func ParsePLS(data []byte) (*Lexicon, error) {
+	var pls plsLexicon
+	if err := xml.Unmarshal(data, &pls); err != nil {
+		return nil, err
+	}
+	var lex Lexicon
+	for _, l := range pls.Lexemes {
+		if len(l.Aliases) == 0 {
+			continue
+		}
+		alias := strings.TrimSpace(l.Aliases[0])
+		for _, g := range l.Graphemes {
+			lex.Rules = append(lex.Rules, Rule{Match: strings.TrimSpace(g), Replace: alias, CaseSensitive: true})
+		}
+	}
+	if err := lex.compile(); err != nil {
+		return nil, err
+	}
+	return &lex, nil
+}
+
+// Merge appends the rules of other lexicons after l's.
+func (l *Lexicon) Merge(others ...*Lexicon) {
+	for _, o := range others {
+		l.Rules = append(l.Rules, o.Rules...)
+	}
+}
+
+func (l *Lexicon) compile() error {
+	for i := range l.Rules {
+		r := &l.Rules[i]
+		if r.Match == "" {
+			return fmt.Errorf("rule %d: match is empty", i+1)
+		}
+		pattern := r.Match
+		if !r.Regex {
+			pattern = regexp.QuoteMeta(r.Match)
+			if r.WholeWord == nil || *r.WholeWord {
+				pattern = wordBoundaries(r.Match, pattern)
+			}
+		}
+		if !r.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("rule %d (%q): %w", i+1, r.Match, err)
+		}
+		r.re = re
+	}
+	return nil
+}
+
+// wordBoundaries anchors pattern at word boundaries, but only on sides where match starts or ends with a
+// word character, so entries like "C++" still match.
+func wordBoundaries(match, pattern string) string {
+	first, _ := utf8.DecodeRuneInString(match)
+	last, _ := utf8.DecodeLastRuneInString(match)
+	if isWordRune(first) {
+		pattern = `\b` + pattern
+	}
+	if isWordRune(last) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Apply rewrites text with every rule in order.
+func (l *Lexicon) Apply(text string) string {
+	if l == nil {
+		return text
+	}
+	for _, r := range l.Rules {
+		if r.re == nil {
+			continue
+		}
+		if r.Regex {
+			text = r.re.ReplaceAllString(text, r.Replace)
+		} else {
+			text = r.re.ReplaceAllLiteralString(text, r.Replace)
+		}
+	}
+	return text
+}
+
+// Replacements lists the distinct matches in text with their expanded replacements, for providers that
+// take word → replacement pairs instead of rewritten text.
+func (l *Lexicon) Replacements(text string) []Replacement {
+	if l == nil {
+		return nil
+	}
+	var out []Replacement
+	seen := map[string]bool{}
+	for _, r := range l.Rules {
+		if r.re == nil {
+			continue
+		}
+		for _, m := range r.re.FindAllStringSubmatchIndex(text, -1) {
+			matched := text[m[0]:m[1]]
+			if matched == "" || seen[matched] {
+				continue
+			}
+			seen[matched] = true
+			replace := r.Replace
+			if r.Regex {
+				replace = string(r.re.ExpandString(nil, r.Replace, text, m))
+			}
+			out = append(out, Replacement{Text: matched, Replace: replace})
+		}
+	}
+	return out
+}
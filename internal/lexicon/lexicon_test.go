@@ -0,0 +1,112 @@
+package lexicon
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApplyLiteralRules(t *testing.T) {
+	lex, err := ParseYAML([]byte(`
+rules:
+  - match: sag
+    replace: sagg
+  - match: API
+    replace: A P I
+    case_sensitive: true
+  - match: Kube
+    replace: koob
+    whole_word: false
+  - match: C++
+    replace: C plus plus
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := lex.Apply("Sag's API calls the api. Kubernetes, massage, C++ and C++17.")
+	want := "sagg's A P I calls the api. koobrnetes, massage, C plus plus and C plus plus17."
+	if got != want {
+		t.Fatalf("Apply =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestApplyRegexRules(t *testing.T) {
+	lex, err := ParseYAML([]byte(`
+rules:
+  - match: 'v(\d+)\.(\d+)'
+    replace: 'version $1 point $2'
+    regex: true
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := lex.Apply("Ship V2.5 today"); got != "Ship version 2 point 5 today" {
+		t.Fatalf("Apply = %q", got)
+	}
+	want := []Replacement{{Text: "v1.0", Replace: "version 1 point 0"}, {Text: "v2.1", Replace: "version 2 point 1"}}
+	if got := lex.Replacements("v1.0 then v2.1 then v1.0"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Replacements = %+v", got)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for name, doc := range map[string]string{
+		"empty match": "rules:\n  - replace: x\n",
+		"bad regex":   "rules:\n  - match: '('\n    replace: x\n    regex: true\n",
+		"bad yaml":    "rules: [",
+	} {
+		if _, err := ParseYAML([]byte(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParsePLS(t *testing.T) {
+	lex, err := ParsePLS([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<lexicon version="1.0" xmlns="http://www.w3.org/2005/01/pronunciation-lexicon" alphabet="ipa" xml:lang="en-US">
+  <lexeme><grapheme>UN</grapheme><grapheme>U.N.</grapheme><alias>United Nations</alias></lexeme>
+  <lexeme><grapheme>tomato</grapheme><phoneme>təˈmeɪtoʊ</phoneme></lexeme>
+</lexicon>`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(lex.Rules) != 2 {
+		t.Fatalf("expected 2 alias rules, got %d", len(lex.Rules))
+	}
+	if got := lex.Apply("The UN and the U.N. met; un-named tomato."); got != "The United Nations and the United Nations met; un-named tomato." {
+		t.Fatalf("Apply = %q", got)
+	}
+}
+
+func TestLoadDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.yml":  "rules:\n  - match: gif\n    replace: jif\n",
+		"b.pls":  `<lexicon><lexeme><grapheme>gif</grapheme><alias>jif</alias></lexeme></lexicon>`,
+		"c.dict": `<lexicon><lexeme><grapheme>gif</grapheme><alias>jif</alias></lexeme></lexicon>`,
+	}
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		lex, err := Load(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := lex.Apply("a gif"); got != "a jif" {
+			t.Fatalf("%s: Apply = %q", name, got)
+		}
+	}
+}
+
+func TestNilLexicon(t *testing.T) {
+	var lex *Lexicon
+	if got := lex.Apply("text"); got != "text" {
+		t.Fatalf("Apply = %q", got)
+	}
+	if got := lex.Replacements("text"); got != nil {
+		t.Fatalf("Replacements = %v", got)
+	}
+}
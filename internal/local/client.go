@@ -0,0 +1,209 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultCommand runs piper, reading text on stdin and writing WAV to stdout.
+const DefaultCommand = "piper --model {model} --length_scale {length_scale} --output_file -"
+
+// Client synthesizes speech by running a local engine command.
+type Client struct {
+	command   string
+	modelsDir string
+}
+
+// NewClient returns a Client for the given command template and models directory.
+//
+// The command is split on whitespace (no shell) and each argument may contain the
+// placeholders {voice} (voice ID), {model} (path to the voice file in the models
+// directory, or the voice ID when no file matches), {speed}, and {length_scale}
+// (1/speed, as piper expects). The engine must read text on stdin and write WAV to stdout.
+func NewClient(command, modelsDir string) *Client {
+	if strings.TrimSpace(command) == "" {
+		command = DefaultCommand
+	}
+	return &Client{
+		command:   command,
+		modelsDir: modelsDir,
+	}
+}
+
+// Voice represents a voice model found in the models directory.
+type Voice struct {
+	VoiceID string
+	Name    string
+	Path    string
+}
+
+// ListVoices returns the voice models in the models directory, sorted by ID.
+// Files ending in .json (piper model configs) and hidden files are skipped.
+func (c *Client) ListVoices(_ context.Context) ([]Voice, error) {
+	if c.modelsDir == "" {
+		return nil, errors.New("local models directory not configured")
+	}
+	entries, err := os.ReadDir(c.modelsDir)
+	if err != nil {
+		return nil, fmt.Errorf("read models directory: %w", err)
+	}
+	voices := make([]Voice, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || strings.EqualFold(filepath.Ext(name), ".json") {
+			continue
+		}
+		id := strings.TrimSuffix(name, filepath.Ext(name))
+		voices = append(voices, Voice{
+			VoiceID: id,
+			Name:    strings.ReplaceAll(id, "_", " "),
+			Path:    filepath.Join(c.modelsDir, name),
+		})
+	}
+	sort.Slice(voices, func(i, j int) bool { return voices[i].VoiceID < voices[j].VoiceID })
+	return voices, nil
+}
+
+// TTSRequest configures a local synthesis run.
+type TTSRequest struct {
+	Text  string
+	Speed float64
+}
+
+// StreamTTS starts the engine and returns its stdout. Reading to EOF reports a
+// non-zero engine exit as an error; Close stops the engine if it is still running.
+func (c *Client) StreamTTS(ctx context.Context, voiceID string, req TTSRequest) (io.ReadCloser, error) {
+	args, err := c.commandArgs(voiceID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(req.Text)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("start local engine: %w", err)
+	}
+	return &engineOutput{ReadCloser: stdout, cmd: cmd, cancel: cancel, stderr: stderr}, nil
+}
+
+// ConvertTTS runs the engine to completion and returns its output.
+func (c *Client) ConvertTTS(ctx context.Context, voiceID string, req TTSRequest) ([]byte, error) {
+	rc, err := c.StreamTTS(ctx, voiceID, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("local engine produced no audio")
+	}
+	return data, nil
+}
+
+func (c *Client) commandArgs(voiceID string, req TTSRequest) ([]string, error) {
+	fields := strings.Fields(c.command)
+	if len(fields) == 0 {
+		return nil, errors.New("local engine command is empty")
+	}
+	speed := req.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	replacer := strings.NewReplacer(
+		"{voice}", voiceID,
+		"{model}", c.modelPath(voiceID),
+		"{speed}", strconv.FormatFloat(speed, 'f', -1, 64),
+		"{length_scale}", strconv.FormatFloat(1/speed, 'f', 3, 64),
+	)
+	args := make([]string, len(fields))
+	for i, f := range fields {
+		args[i] = replacer.Replace(f)
+	}
+	return args, nil
+}
+
+// modelPath maps a voice ID to its file in the models directory, falling back to the ID itself.
+func (c *Client) modelPath(voiceID string) string {
+	if c.modelsDir == "" || voiceID == "" {
+		return voiceID
+	}
+	if filepath.IsAbs(voiceID) {
+		return voiceID
+	}
+	matches, _ := filepath.Glob(filepath.Join(c.modelsDir, globEscape(voiceID)+".*"))
+	for _, m := range matches {
+		if !strings.EqualFold(filepath.Ext(m), ".json") {
+			return m
+		}
+	}
+	if _, err := os.Stat(filepath.Join(c.modelsDir, voiceID)); err == nil {
+		return filepath.Join(c.modelsDir, voiceID)
+	}
+	return voiceID
+}
+
+func globEscape(s string) string {
+	return strings.NewReplacer(`*`, `\*`, `?`, `\?`, `[`, `\[`, `\`, `\\`).Replace(s)
+}
+
+type engineOutput struct {
+	io.ReadCloser
+	cmd     *exec.Cmd
+	cancel  func()
+	stderr  *bytes.Buffer
+	waited  bool
+	waitErr error
+}
+
+func (o *engineOutput) Read(p []byte) (int, error) {
+	n, err := o.ReadCloser.Read(p)
+	if err == io.EOF {
+		if werr := o.wait(); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+func (o *engineOutput) Close() error {
+	o.cancel()
+	_ = o.wait()
+	return nil
+}
+
+func (o *engineOutput) wait() error {
+	if o.waited {
+		return o.waitErr
+	}
+	o.waited = true
+	if err := o.cmd.Wait(); err != nil {
+		msg := strings.TrimSpace(o.stderr.String())
+		if msg != "" {
+			o.waitErr = fmt.Errorf("local engine failed: %w: %s", err, msg)
+		} else {
+			o.waitErr = fmt.Errorf("local engine failed: %w", err)
+		}
+	}
+	return o.waitErr
+}
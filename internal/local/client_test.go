@@ -0,0 +1,89 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeStubEngine writes a shell script that echoes its args and stdin, standing in for piper.
+func writeStubEngine(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub engine requires a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "engine.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatalf("write stub engine: %v", err)
+	}
+	return path
+}
+
+func TestListVoicesSkipsConfigsAndHiddenFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"en_US-lessac-medium.onnx", "en_US-lessac-medium.onnx.json", ".DS_Store", "de_DE-thorsten-low.onnx"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("write model: %v", err)
+		}
+	}
+
+	voices, err := NewClient("", dir).ListVoices(context.Background())
+	if err != nil {
+		t.Fatalf("ListVoices error: %v", err)
+	}
+	if len(voices) != 2 {
+		t.Fatalf("expected 2 voices, got %+v", voices)
+	}
+	if voices[0].VoiceID != "de_DE-thorsten-low" || voices[1].VoiceID != "en_US-lessac-medium" {
+		t.Fatalf("unexpected voices: %+v", voices)
+	}
+}
+
+func TestListVoicesMissingDir(t *testing.T) {
+	if _, err := NewClient("", filepath.Join(t.TempDir(), "missing")).ListVoices(context.Background()); err == nil {
+		t.Fatalf("expected error for missing models directory")
+	}
+}
+
+func TestStreamTTSSubstitutesPlaceholders(t *testing.T) {
+	dir := t.TempDir()
+	model := filepath.Join(dir, "alpha.onnx")
+	if err := os.WriteFile(model, nil, 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	engine := writeStubEngine(t, `printf 'args=%s|%s|%s|%s\n' "$1" "$2" "$3" "$4"; cat`)
+
+	c := NewClient(engine+" {voice} {model} {speed} {length_scale}", dir)
+	rc, err := c.StreamTTS(context.Background(), "alpha", TTSRequest{Text: "hello", Speed: 2})
+	if err != nil {
+		t.Fatalf("StreamTTS error: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	out, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	want := "args=alpha|" + model + "|2|0.500\nhello"
+	if string(out) != want {
+		t.Fatalf("engine output = %q, want %q", string(out), want)
+	}
+}
+
+func TestConvertTTSReportsEngineFailure(t *testing.T) {
+	engine := writeStubEngine(t, `echo "model not found" >&2; exit 3`)
+	_, err := NewClient(engine, "").ConvertTTS(context.Background(), "x", TTSRequest{Text: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Fatalf("expected engine stderr in error, got %v", err)
+	}
+}
+
+func TestConvertTTSMissingBinary(t *testing.T) {
+	_, err := NewClient("/nonexistent/sag-engine", "").ConvertTTS(context.Background(), "x", TTSRequest{Text: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "start local engine") {
+		t.Fatalf("expected start error, got %v", err)
+	}
+}
@@ -0,0 +1,2 @@
+// Package local runs an offline TTS engine binary (piper, espeak-ng, ...) as a speech backend.
+package local
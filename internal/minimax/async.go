@@ -0,0 +1,246 @@
+package minimax
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Async task statuses reported by MiniMax.
+const (
+	AsyncStatusProcessing = "Processing"
+	AsyncStatusSuccess    = "Success"
+	AsyncStatusFailed     = "Failed"
+	AsyncStatusExpired    = "Expired"
+)
+
+// MaxAsyncInlineChars is the longest text sent inline; longer input is uploaded as a file.
+const MaxAsyncInlineChars = 50000
+
+// AsyncTask describes a long-text synthesis job.
+type AsyncTask struct {
+	TaskID          int64  `json:"task_id"`
+	Status          string `json:"status,omitempty"`
+	FileID          int64  `json:"file_id,omitempty"`
+	UsageCharacters int    `json:"usage_characters,omitempty"`
+}
+
+type asyncAudioSetting struct {
+	Format     string `json:"format,omitempty"`
+	SampleRate int    `json:"audio_sample_rate,omitempty"`
+	Bitrate    int    `json:"bitrate,omitempty"`
+	Channel    int    `json:"channel,omitempty"`
+}
+
+type asyncRequest struct {
+	Model             string             `json:"model"`
+	Text              string             `json:"text,omitempty"`
+	TextFileID        int64              `json:"text_file_id,omitempty"`
+	VoiceSetting      voiceSetting       `json:"voice_setting"`
+	AudioSetting      asyncAudioSetting  `json:"audio_setting,omitempty"`
+	LanguageBoost     string             `json:"language_boost,omitempty"`
+	PronunciationDict *PronunciationDict `json:"pronunciation_dict,omitempty"`
+	VoiceModify       *VoiceModify       `json:"voice_modify,omitempty"`
+}
+
+type asyncResponse struct {
+	AsyncTask
+	BaseResp *baseResp `json:"base_resp,omitempty"`
+}
+
+// CreateAsyncTTS submits a long-text job. Text longer than MaxAsyncInlineChars is uploaded first.
+func (c *Client) CreateAsyncTTS(ctx context.Context, voiceID string, req TTSRequest) (AsyncTask, error) {
+	payload := asyncRequest{
+		Model:        req.Model,
+		VoiceSetting: buildVoiceSetting(voiceID, req),
+		AudioSetting: asyncAudioSetting{
+			Format:     req.AudioFormat,
+			SampleRate: req.SampleRate,
+			Bitrate:    req.Bitrate,
+			Channel:    req.Channel,
+		},
+		LanguageBoost:     req.LanguageBoost,
+		PronunciationDict: req.PronunciationDict,
+		VoiceModify:       req.VoiceModify,
+	}
+	if len([]rune(req.Text)) > MaxAsyncInlineChars {
+		fileID, err := c.UploadFile(ctx, "t2a_async_input", "input.txt", strings.NewReader(req.Text))
+		if err != nil {
+			return AsyncTask{}, err
+		}
+		payload.TextFileID = fileID
+	} else {
+		payload.Text = req.Text
+	}
+
+	var resp asyncResponse
+	if err := c.postJSON(ctx, "/v1/t2a_async_v2", payload, &resp); err != nil {
+		return AsyncTask{}, fmt.Errorf("create async task failed: %w", err)
+	}
+	if err := resp.BaseResp.err(); err != nil {
+		return AsyncTask{}, err
+	}
+	if resp.TaskID == 0 {
+		return AsyncTask{}, errors.New("minimax response missing task_id")
+	}
+	if resp.Status == "" {
+		resp.Status = AsyncStatusProcessing
+	}
+	return resp.AsyncTask, nil
+}
+
+// QueryAsyncTTS returns the current status of a job.
+func (c *Client) QueryAsyncTTS(ctx context.Context, taskID int64) (AsyncTask, error) {
+	u, err := c.httpURL("/v1/query/t2a_async_query_v2")
+	if err != nil {
+		return AsyncTask{}, err
+	}
+	u += "?" + url.Values{"task_id": {strconv.FormatInt(taskID, 10)}}.Encode()
+
+	body, err := c.get(ctx, c.httpClient, u)
+	if err != nil {
+		return AsyncTask{}, fmt.Errorf("query async task failed: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	var resp asyncResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return AsyncTask{}, err
+	}
+	if err := resp.BaseResp.err(); err != nil {
+		return AsyncTask{}, err
+	}
+	return resp.AsyncTask, nil
+}
+
+// DownloadFile streams the content of an uploaded or generated file.
+// Generated audiobooks can be large, so only ctx bounds the download time.
+func (c *Client) DownloadFile(ctx context.Context, fileID int64) (io.ReadCloser, error) {
+	u, err := c.httpURL("/v1/files/retrieve_content")
+	if err != nil {
+		return nil, err
+	}
+	u += "?" + url.Values{"file_id": {strconv.FormatInt(fileID, 10)}}.Encode()
+	body, err := c.get(ctx, &http.Client{Transport: c.httpClient.Transport}, u)
+	if err != nil {
+		return nil, fmt.Errorf("download file failed: %w", err)
+	}
+	return body, nil
+}
+
+type uploadResponse struct {
+	File struct {
+		FileID int64 `json:"file_id"`
+	} `json:"file"`
+	BaseResp *baseResp `json:"base_resp,omitempty"`
+}
+
+// UploadFile uploads r under the given purpose (e.g. t2a_async_input, voice_clone) and returns its file ID.
+func (c *Client) UploadFile(ctx context.Context, purpose, filename string, r io.Reader) (int64, error) {
+	u, err := c.httpURL("/v1/files/upload")
+	if err != nil {
+		return 0, err
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("purpose", purpose); err != nil {
+		return 0, err
+	}
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return 0, err
+	}
+	if err := mw.Close(); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, &buf)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("upload file failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var payload uploadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return 0, err
+	}
+	if err := payload.BaseResp.err(); err != nil {
+		return 0, err
+	}
+	if payload.File.FileID == 0 {
+		return 0, errors.New("minimax upload response missing file_id")
+	}
+	return payload.File.FileID, nil
+}
+
+func (c *Client) postJSON(ctx context.Context, endpoint string, in, out any) error {
+	u, err := c.httpURL(endpoint)
+	if err != nil {
+		return err
+	}
+	bodyBytes, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *Client) get(ctx context.Context, client *http.Client, u string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp.Body, nil
+}
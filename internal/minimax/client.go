@@ -125,22 +125,24 @@ func (c *Client) ListVoices(ctx context.Context) ([]Voice, error) {
 
 // TTSRequest configures a text-to-speech request payload.
 type TTSRequest struct {
-	Model             string
-	Text              string
-	Speed             *float64
-	Volume            *float64
-	Pitch             *int
-	Emotion           string
-	TextNormalization *bool
-	LatexRead         *bool
-	AudioFormat       string
-	SampleRate        int
-	Bitrate           int
-	Channel           int
-	LanguageBoost     string
-	ContinuousSound   *bool
-	PronunciationDict *PronunciationDict
-	VoiceModify       *VoiceModify
+	Model             string             `json:"model"`
+	Text              string             `json:"text"`
+	Speed             *float64           `json:"speed,omitempty"`
+	Volume            *float64           `json:"vol,omitempty"`
+	Pitch             *int               `json:"pitch,omitempty"`
+	Emotion           string             `json:"emotion,omitempty"`
+	TextNormalization *bool              `json:"text_normalization,omitempty"`
+	LatexRead         *bool              `json:"latex_read,omitempty"`
+	AudioFormat       string             `json:"format,omitempty"`
+	SampleRate        int                `json:"sample_rate,omitempty"`
+	Bitrate           int                `json:"bitrate,omitempty"`
+	Channel           int                `json:"channel,omitempty"`
+	LanguageBoost     string             `json:"language_boost,omitempty"`
+	ContinuousSound   *bool              `json:"continuous_sound,omitempty"`
+	PronunciationDict *PronunciationDict `json:"pronunciation_dict,omitempty"`
+	VoiceModify       *VoiceModify       `json:"voice_modify,omitempty"`
+	// SubtitleEnable asks for a sentence/word timing file (non-streaming requests only).
+	SubtitleEnable bool `json:"subtitle_enable,omitempty"`
 }
 
 type baseResp struct {
@@ -204,6 +206,7 @@ type t2aRequest struct {
 	ContinuousSound   *bool              `json:"continuous_sound,omitempty"`
 	PronunciationDict *PronunciationDict `json:"pronunciation_dict,omitempty"`
 	VoiceModify       *VoiceModify       `json:"voice_modify,omitempty"`
+	SubtitleEnable    bool               `json:"subtitle_enable,omitempty"`
 }
 
 type t2aStreamOptions struct {
@@ -212,7 +215,8 @@ type t2aStreamOptions struct {
 
 type t2aResponse struct {
 	Data struct {
-		Audio string `json:"audio"`
+		Audio        string `json:"audio"`
+		SubtitleFile string `json:"subtitle_file"`
 	} `json:"data"`
 	BaseResp *baseResp `json:"base_resp,omitempty"`
 }
@@ -227,12 +231,71 @@ type t2aStreamResponse struct {
 	BaseResp *baseResp      `json:"base_resp,omitempty"`
 }
 
+// SubtitleSegment is one sentence from a MiniMax subtitle file. Times are in milliseconds.
+type SubtitleSegment struct {
+	Text      string         `json:"text"`
+	TimeBegin float64        `json:"time_begin"`
+	TimeEnd   float64        `json:"time_end"`
+	Words     []SubtitleWord `json:"timestamped_words,omitempty"`
+}
+
+// SubtitleWord is a word-level timing inside a SubtitleSegment, when MiniMax provides one.
+type SubtitleWord struct {
+	Word      string  `json:"word"`
+	TimeBegin float64 `json:"time_begin"`
+	TimeEnd   float64 `json:"time_end"`
+}
+
 // ConvertTTS downloads the full audio before returning.
 func (c *Client) ConvertTTS(ctx context.Context, voiceID string, req TTSRequest) ([]byte, error) {
-	u, err := c.httpURL("/v1/t2a_v2")
+	data, _, err := c.convert(ctx, voiceID, req)
+	return data, err
+}
+
+// ConvertTTSWithSubtitles requests subtitles alongside the audio and downloads the subtitle file.
+func (c *Client) ConvertTTSWithSubtitles(ctx context.Context, voiceID string, req TTSRequest) ([]byte, []SubtitleSegment, error) {
+	req.SubtitleEnable = true
+	data, subtitleURL, err := c.convert(ctx, voiceID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if subtitleURL == "" {
+		return nil, nil, errors.New("minimax response missing subtitle_file")
+	}
+	segments, err := c.fetchSubtitles(ctx, subtitleURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, segments, nil
+}
+
+func (c *Client) fetchSubtitles(ctx context.Context, subtitleURL string) ([]SubtitleSegment, error) {
+	// The subtitle file is a pre-signed download URL; it must not receive the API key.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subtitleURL, nil)
 	if err != nil {
 		return nil, err
 	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("download subtitles failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	var segments []SubtitleSegment
+	if err := json.NewDecoder(resp.Body).Decode(&segments); err != nil {
+		return nil, fmt.Errorf("decode subtitles: %w", err)
+	}
+	return segments, nil
+}
+
+func (c *Client) convert(ctx context.Context, voiceID string, req TTSRequest) ([]byte, string, error) {
+	u, err := c.httpURL("/v1/t2a_v2")
+	if err != nil {
+		return nil, "", err
+	}
 
 	payload := t2aRequest{
 		Model:             req.Model,
@@ -245,15 +308,16 @@ func (c *Client) ConvertTTS(ctx context.Context, voiceID string, req TTSRequest)
 		ContinuousSound:   req.ContinuousSound,
 		PronunciationDict: req.PronunciationDict,
 		VoiceModify:       req.VoiceModify,
+		SubtitleEnable:    req.SubtitleEnable,
 	}
 	bodyBytes, err := json.Marshal(payload)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -261,31 +325,31 @@ func (c *Client) ConvertTTS(ctx context.Context, voiceID string, req TTSRequest)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("convert TTS failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		return nil, "", fmt.Errorf("convert TTS failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
 	}
 
 	var response t2aResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if err := response.BaseResp.err(); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if response.Data.Audio == "" {
-		return nil, errors.New("minimax response missing audio")
+		return nil, "", errors.New("minimax response missing audio")
 	}
 
 	data, err := hex.DecodeString(response.Data.Audio)
 	if err != nil {
-		return nil, fmt.Errorf("decode audio hex: %w", err)
+		return nil, "", fmt.Errorf("decode audio hex: %w", err)
 	}
-	return data, nil
+	return data, response.Data.SubtitleFile, nil
 }
 
 type cancelReadCloser struct {
@@ -0,0 +1,51 @@
+package minimax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// VoiceCloneRequest configures a voice clone from an uploaded sample (see UploadFile with purpose voice_clone).
+type VoiceCloneRequest struct {
+	FileID              int64  `json:"file_id"`
+	VoiceID             string `json:"voice_id"`
+	NoiseReduction      bool   `json:"need_noise_reduction,omitempty"`
+	VolumeNormalization bool   `json:"need_volume_normalization,omitempty"`
+	PreviewText         string `json:"text,omitempty"`
+	PreviewModel        string `json:"model,omitempty"`
+}
+
+// VoiceCloneResult is the outcome of a clone. DemoAudioURL is only set when preview text was given.
+type VoiceCloneResult struct {
+	DemoAudioURL   string
+	InputSensitive bool
+}
+
+type voiceCloneResponse struct {
+	DemoAudio      string    `json:"demo_audio"`
+	InputSensitive bool      `json:"input_sensitive"`
+	BaseResp       *baseResp `json:"base_resp,omitempty"`
+}
+
+// CloneVoice creates a cloned voice. The voice is listed under voice_cloning once it has been used.
+func (c *Client) CloneVoice(ctx context.Context, req VoiceCloneRequest) (VoiceCloneResult, error) {
+	if req.FileID == 0 {
+		return VoiceCloneResult{}, errors.New("voice clone requires an uploaded file_id")
+	}
+	if req.VoiceID == "" {
+		return VoiceCloneResult{}, errors.New("voice clone requires a voice_id")
+	}
+	if req.PreviewText != "" && req.PreviewModel == "" {
+		return VoiceCloneResult{}, errors.New("voice clone preview text requires a model")
+	}
+
+	var resp voiceCloneResponse
+	if err := c.postJSON(ctx, "/v1/voice_clone", req, &resp); err != nil {
+		return VoiceCloneResult{}, fmt.Errorf("voice clone failed: %w", err)
+	}
+	if err := resp.BaseResp.err(); err != nil {
+		return VoiceCloneResult{}, err
+	}
+	return VoiceCloneResult{DemoAudioURL: resp.DemoAudio, InputSensitive: resp.InputSensitive}, nil
+}
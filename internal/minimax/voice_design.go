@@ -0,0 +1,39 @@
+package minimax
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+type voiceDesignRequest struct {
+	Prompt      string `json:"prompt"`
+	PreviewText string `json:"preview_text"`
+}
+
+type voiceDesignResponse struct {
+	VoiceID    string    `json:"voice_id"`
+	TrialAudio string    `json:"trial_audio"`
+	BaseResp   *baseResp `json:"base_resp,omitempty"`
+}
+
+// DesignVoice generates one voice from a prompt and returns its ID with MP3 audio of previewText.
+// Like cloned voices, designed voices are only kept after their first use in speech synthesis.
+func (c *Client) DesignVoice(ctx context.Context, prompt, previewText string) (string, []byte, error) {
+	var resp voiceDesignResponse
+	if err := c.postJSON(ctx, "/v1/voice_design", voiceDesignRequest{Prompt: prompt, PreviewText: previewText}, &resp); err != nil {
+		return "", nil, fmt.Errorf("voice design failed: %w", err)
+	}
+	if err := resp.BaseResp.err(); err != nil {
+		return "", nil, err
+	}
+	if resp.VoiceID == "" {
+		return "", nil, errors.New("minimax voice design response missing voice_id")
+	}
+	audio, err := hex.DecodeString(resp.TrialAudio)
+	if err != nil {
+		return "", nil, fmt.Errorf("decode trial audio: %w", err)
+	}
+	return resp.VoiceID, audio, nil
+}
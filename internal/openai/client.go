@@ -0,0 +1,191 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://api.openai.com"
+
+// BuiltinVoices are the voices offered by OpenAI's hosted TTS models.
+var BuiltinVoices = []string{"alloy", "ash", "ballad", "coral", "echo", "fable", "nova", "onyx", "sage", "shimmer", "verse"}
+
+// Client talks to an OpenAI-compatible speech API (OpenAI, Kokoro, openedai-speech, LocalAI, ...).
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewClient returns a client configured with the given API key and base URL.
+// The base URL may include a trailing /v1, as self-hosted servers often document it that way.
+func NewClient(apiKey, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Client{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// Voice represents a voice offered by the server.
+type Voice struct {
+	VoiceID string
+	Name    string
+}
+
+type listVoicesResponse struct {
+	Voices []json.RawMessage `json:"voices"`
+}
+
+// ListVoices returns the server's voices from GET /v1/audio/voices when available
+// (Kokoro and others expose it) and falls back to OpenAI's built-in voices.
+func (c *Client) ListVoices(ctx context.Context) ([]Voice, error) {
+	u, err := c.endpoint("/v1/audio/voices")
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err == nil {
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode < 400 {
+			var body listVoicesResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && len(body.Voices) > 0 {
+				return decodeVoices(body.Voices), nil
+			}
+		}
+	}
+
+	voices := make([]Voice, 0, len(BuiltinVoices))
+	for _, id := range BuiltinVoices {
+		voices = append(voices, Voice{VoiceID: id, Name: id})
+	}
+	return voices, nil
+}
+
+// decodeVoices accepts both plain string entries and {"id"/"voice_id","name"} objects.
+func decodeVoices(raw []json.RawMessage) []Voice {
+	voices := make([]Voice, 0, len(raw))
+	for _, item := range raw {
+		var id string
+		if err := json.Unmarshal(item, &id); err == nil {
+			voices = append(voices, Voice{VoiceID: id, Name: id})
+			continue
+		}
+		var obj struct {
+			ID      string `json:"id"`
+			VoiceID string `json:"voice_id"`
+			Name    string `json:"name"`
+		}
+		if err := json.Unmarshal(item, &obj); err != nil {
+			continue
+		}
+		v := Voice{VoiceID: obj.ID, Name: obj.Name}
+		if v.VoiceID == "" {
+			v.VoiceID = obj.VoiceID
+		}
+		if v.Name == "" {
+			v.Name = v.VoiceID
+		}
+		if v.VoiceID != "" {
+			voices = append(voices, v)
+		}
+	}
+	return voices
+}
+
+// TTSRequest configures a /v1/audio/speech request payload.
+type TTSRequest struct {
+	Model          string   `json:"model"`
+	Input          string   `json:"input"`
+	Voice          string   `json:"voice"`
+	ResponseFormat string   `json:"response_format,omitempty"`
+	Speed          *float64 `json:"speed,omitempty"`
+}
+
+// StreamTTS requests speech and returns the audio body as it arrives.
+func (c *Client) StreamTTS(ctx context.Context, voiceID string, payload TTSRequest) (io.ReadCloser, error) {
+	resp, err := c.speech(ctx, voiceID, payload)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ConvertTTS downloads the full audio before returning.
+func (c *Client) ConvertTTS(ctx context.Context, voiceID string, payload TTSRequest) ([]byte, error) {
+	resp, err := c.speech(ctx, voiceID, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return io.ReadAll(resp.Body)
+}
+
+func (c *Client) speech(ctx context.Context, voiceID string, payload TTSRequest) (*http.Response, error) {
+	u, err := c.endpoint("/v1/audio/speech")
+	if err != nil {
+		return nil, err
+	}
+	payload.Voice = voiceID
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer func() { _ = resp.Body.Close() }()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("speech request failed: %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	return resp, nil
+}
+
+func (c *Client) authorize(req *http.Request) {
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+}
+
+func (c *Client) endpoint(endpoint string) (string, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", err
+	}
+	base := strings.TrimSuffix(u.Path, "/")
+	if strings.HasSuffix(base, "/v1") {
+		endpoint = strings.TrimPrefix(endpoint, "/v1")
+	}
+	u.Path = path.Join(base, endpoint)
+	return u.String(), nil
+}
@@ -0,0 +1,120 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewClientDefaultsBase(t *testing.T) {
+	c := NewClient("key", "")
+	if c.baseURL != "https://api.openai.com" {
+		t.Fatalf("unexpected baseURL: %s", c.baseURL)
+	}
+}
+
+func TestStreamTTSPayload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/speech" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Fatalf("unexpected Authorization header: %q", r.Header.Get("Authorization"))
+		}
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if got["model"] != "tts-1" || got["voice"] != "nova" || got["input"] != "hi" || got["response_format"] != "wav" || got["speed"] != 1.25 {
+			t.Fatalf("unexpected payload: %v", got)
+		}
+		_, _ = w.Write([]byte("audio"))
+	}))
+	defer srv.Close()
+
+	speed := 1.25
+	rc, err := NewClient("key", srv.URL).StreamTTS(context.Background(), "nova", TTSRequest{
+		Model:          "tts-1",
+		Input:          "hi",
+		ResponseFormat: "wav",
+		Speed:          &speed,
+	})
+	if err != nil {
+		t.Fatalf("StreamTTS error: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	b, _ := io.ReadAll(rc)
+	if string(b) != "audio" {
+		t.Fatalf("unexpected body: %q", string(b))
+	}
+}
+
+func TestBaseURLWithV1Suffix(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/speech" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Fatalf("expected no Authorization header without a key")
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	data, err := NewClient("", srv.URL+"/v1").ConvertTTS(context.Background(), "af_bella", TTSRequest{Model: "kokoro", Input: "hi"})
+	if err != nil {
+		t.Fatalf("ConvertTTS error: %v", err)
+	}
+	if string(data) != "ok" {
+		t.Fatalf("unexpected data: %q", string(data))
+	}
+}
+
+func TestConvertTTSError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "bad voice", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient("key", srv.URL).ConvertTTS(context.Background(), "x", TTSRequest{Model: "tts-1", Input: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "bad voice") {
+		t.Fatalf("expected 400 error, got %v", err)
+	}
+}
+
+func TestListVoicesFromServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/voices" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"voices":["af_bella",{"id":"am_adam","name":"Adam"}]}`))
+	}))
+	defer srv.Close()
+
+	voices, err := NewClient("", srv.URL).ListVoices(context.Background())
+	if err != nil {
+		t.Fatalf("ListVoices error: %v", err)
+	}
+	if len(voices) != 2 || voices[0].VoiceID != "af_bella" || voices[1].Name != "Adam" {
+		t.Fatalf("unexpected voices: %+v", voices)
+	}
+}
+
+func TestListVoicesFallsBackToBuiltins(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	voices, err := NewClient("key", srv.URL).ListVoices(context.Background())
+	if err != nil {
+		t.Fatalf("ListVoices error: %v", err)
+	}
+	if len(voices) != len(BuiltinVoices) {
+		t.Fatalf("expected builtin voices, got %+v", voices)
+	}
+}
@@ -0,0 +1,2 @@
+// Package openai provides a small client for OpenAI-compatible /v1/audio/speech servers.
+package openai
@@ -0,0 +1,2 @@
+// Package subtitles groups character timings into words and cues and writes SRT/WebVTT files.
+package subtitles
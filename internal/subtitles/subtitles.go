@@ -0,0 +1,204 @@
+package subtitles
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Char is one character of the spoken text with its timing in seconds.
+type Char struct {
+	Text  string  `json:"text"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Word is a whitespace-delimited run of characters with its timing in seconds.
+type Word struct {
+	Text  string  `json:"text"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Timings is what a provider reported about when the text was spoken: characters, words, or both.
+type Timings struct {
+	Chars []Char
+	Words []Word
+}
+
+// AllWords returns the reported words, or groups the characters into words when only those are known.
+func (t Timings) AllWords() []Word {
+	if len(t.Words) > 0 {
+		return t.Words
+	}
+	return Words(t.Chars)
+}
+
+// Cue is one subtitle entry.
+type Cue struct {
+	Start float64
+	End   float64
+	Text  string
+}
+
+// CueOptions limits how many words are grouped into a cue.
+type CueOptions struct {
+	MaxChars    int
+	MaxDuration float64
+}
+
+// DefaultCueOptions follows common broadcast guidelines (one 42-character line, at most 6 seconds).
+var DefaultCueOptions = CueOptions{MaxChars: 42, MaxDuration: 6}
+
+// Words groups characters into words, splitting on whitespace.
+func Words(chars []Char) []Word {
+	var words []Word
+	var cur strings.Builder
+	var start, end float64
+	flush := func() {
+		if cur.Len() > 0 {
+			words = append(words, Word{Text: cur.String(), Start: start, End: end})
+			cur.Reset()
+		}
+	}
+	for _, c := range chars {
+		if strings.TrimFunc(c.Text, unicode.IsSpace) == "" {
+			flush()
+			continue
+		}
+		if cur.Len() == 0 {
+			start = c.Start
+		}
+		cur.WriteString(c.Text)
+		end = c.End
+	}
+	flush()
+	return words
+}
+
+// BuildCues groups words into cues, breaking after sentences and when a cue would exceed the limits.
+// Bracketed audio tags such as "[laughs]" are spoken cues for the model, not captions, and are dropped;
+// text attached to a tag, like "Hello" in "[laughs]Hello", is kept.
+func BuildCues(words []Word, opts CueOptions) []Cue {
+	if opts.MaxChars <= 0 {
+		opts.MaxChars = DefaultCueOptions.MaxChars
+	}
+	if opts.MaxDuration <= 0 {
+		opts.MaxDuration = DefaultCueOptions.MaxDuration
+	}
+
+	var cues []Cue
+	var cur *Cue
+	inTag := false
+	for i, w := range words {
+		var text string
+		text, inTag = stripTags(w.Text, inTag, tagClosesLater(words[i+1:]))
+		if text == "" {
+			continue
+		}
+		if strings.TrimFunc(text, unicode.IsPunct) == "" {
+			// Punctuation left over from "[sighs]." belongs to the previous word.
+			if cur != nil {
+				cur.Text += text
+				cur.End = w.End
+				if endsSentence(cur.Text) {
+					cues = append(cues, *cur)
+					cur = nil
+				}
+			}
+			continue
+		}
+		w.Text = text
+		if cur != nil {
+			tooLong := len([]rune(cur.Text))+1+len([]rune(w.Text)) > opts.MaxChars
+			tooSlow := w.End-cur.Start > opts.MaxDuration
+			if tooLong || tooSlow {
+				cues = append(cues, *cur)
+				cur = nil
+			}
+		}
+		if cur == nil {
+			cur = &Cue{Start: w.Start, End: w.End, Text: w.Text}
+		} else {
+			cur.Text += " " + w.Text
+			cur.End = w.End
+		}
+		if endsSentence(w.Text) {
+			cues = append(cues, *cur)
+			cur = nil
+		}
+	}
+	if cur != nil {
+		cues = append(cues, *cur)
+	}
+	return cues
+}
+
+// stripTags removes bracketed spans from word and keeps the rest. inTag says the word starts inside
+// a tag opened by an earlier word, and closesLater that a later word ends an open tag; a "[" that is
+// never closed is kept as text. open reports that a tag is still open at the end of the word.
+func stripTags(word string, inTag, closesLater bool) (text string, open bool) {
+	var b strings.Builder
+	for i, r := range word {
+		switch {
+		case inTag:
+			inTag = r != ']'
+		case r == '[' && (strings.ContainsRune(word[i:], ']') || closesLater):
+			inTag = true
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), inTag
+}
+
+// tagClosesLater reports whether a "]" appears in words before another "[" opens.
+func tagClosesLater(words []Word) bool {
+	for _, w := range words {
+		if i := strings.IndexAny(w.Text, "[]"); i >= 0 {
+			return w.Text[i] == ']'
+		}
+	}
+	return false
+}
+
+func endsSentence(word string) bool {
+	word = strings.TrimRight(word, `"')]»”’`)
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") || strings.HasSuffix(word, "?") || strings.HasSuffix(word, "…")
+}
+
+// WriteSRT writes cues in SubRip format.
+func WriteSRT(w io.Writer, cues []Cue) error {
+	bw := bufio.NewWriter(w)
+	for i, c := range cues {
+		if _, err := fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n\n", i+1, timestamp(c.Start, ","), timestamp(c.End, ","), c.Text); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteVTT writes cues in WebVTT format.
+func WriteVTT(w io.Writer, cues []Cue) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("WEBVTT\n\n"); err != nil {
+		return err
+	}
+	for _, c := range cues {
+		if _, err := fmt.Fprintf(bw, "%s --> %s\n%s\n\n", timestamp(c.Start, "."), timestamp(c.End, "."), c.Text); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func timestamp(seconds float64, fracSep string) string {
+	ms := int64(math.Round(math.Max(seconds, 0) * 1000))
+	h := ms / 3_600_000
+	m := ms / 60_000 % 60
+	s := ms / 1000 % 60
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", h, m, s, fracSep, ms%1000)
+}
@@ -0,0 +1,109 @@
+package subtitles
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func charsFor(text string, step float64) []Char {
+	var out []Char
+	for i, r := range []rune(text) {
+		out = append(out, Char{Text: string(r), Start: float64(i) * step, End: float64(i+1) * step})
+	}
+	return out
+}
+
+func TestWordsSplitsOnWhitespace(t *testing.T) {
+	words := Words(charsFor("Hi  there", 0.1))
+	if len(words) != 2 {
+		t.Fatalf("expected 2 words, got %+v", words)
+	}
+	if words[1].Text != "there" || words[1].Start != 0.4 || words[1].End != 0.9 {
+		t.Fatalf("unexpected second word: %+v", words[1])
+	}
+}
+
+func TestBuildCuesBreaksOnSentencesAndLength(t *testing.T) {
+	words := Words(charsFor("[whispers] Hello world. This line keeps going well past the limit here", 0.05))
+	cues := BuildCues(words, CueOptions{MaxChars: 20, MaxDuration: 10})
+	if len(cues) < 3 {
+		t.Fatalf("expected several cues, got %+v", cues)
+	}
+	if cues[0].Text != "Hello world." {
+		t.Fatalf("unexpected first cue: %q", cues[0].Text)
+	}
+	for _, c := range cues {
+		if len(c.Text) > 20 {
+			t.Fatalf("cue exceeds max chars: %q", c.Text)
+		}
+	}
+}
+
+func TestBuildCuesDropsOnlyTags(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"tag glued to next word", "[laughs]Hello world. Bye now.", []string{"Hello world.", "Bye now."}},
+		{"attached comma", "Well [whispers], then more.", []string{"Well, then more."}},
+		{"attached period", "It ends [sighs]. Next one.", []string{"It ends.", "Next one."}},
+		{"multi-word tag", "[starts laughing] ok then.", []string{"ok then."}},
+		{"unclosed bracket", "See [note one. Two.", []string{"See [note one.", "Two."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cues := BuildCues(Words(charsFor(tt.text, 0.05)), CueOptions{MaxChars: 100, MaxDuration: 10})
+			var got []string
+			for _, c := range cues {
+				got = append(got, c.Text)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCuesRespectsDuration(t *testing.T) {
+	words := []Word{{"one", 0, 1}, {"two", 1, 2}, {"three", 2, 3.5}}
+	cues := BuildCues(words, CueOptions{MaxChars: 100, MaxDuration: 2})
+	if len(cues) != 2 || cues[1].Text != "three" {
+		t.Fatalf("unexpected cues: %+v", cues)
+	}
+}
+
+func TestWriteSRT(t *testing.T) {
+	var buf bytes.Buffer
+	cues := []Cue{{Start: 0, End: 1.5, Text: "Hello"}, {Start: 3661.2, End: 3662, Text: "Later"}}
+	if err := WriteSRT(&buf, cues); err != nil {
+		t.Fatalf("WriteSRT error: %v", err)
+	}
+	want := "1\n00:00:00,000 --> 00:00:01,500\nHello\n\n2\n01:01:01,200 --> 01:01:02,000\nLater\n\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected SRT:\n%s", buf.String())
+	}
+}
+
+func TestWriteVTT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteVTT(&buf, []Cue{{Start: 0.25, End: 1, Text: "Hi"}}); err != nil {
+		t.Fatalf("WriteVTT error: %v", err)
+	}
+	want := "WEBVTT\n\n00:00:00.250 --> 00:00:01.000\nHi\n\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected VTT:\n%s", buf.String())
+	}
+}
+
+func TestTimingsAllWordsPrefersReportedWords(t *testing.T) {
+	reported := Timings{Chars: charsFor("a b", 0.1), Words: []Word{{"ab", 0, 1}}}
+	if got := reported.AllWords(); len(got) != 1 || got[0].Text != "ab" {
+		t.Fatalf("AllWords = %+v, want reported words", got)
+	}
+	derived := Timings{Chars: charsFor("a b", 0.1)}
+	if got := derived.AllWords(); len(got) != 2 {
+		t.Fatalf("AllWords from chars = %+v", got)
+	}
+}
@@ -0,0 +1,2 @@
+// Package wer compares a transcript with the expected text and reports the word error rate.
+package wer
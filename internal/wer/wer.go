@@ -0,0 +1,123 @@
+package wer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Edit kinds reported in Result.Edits.
+const (
+	Substitution = "substitution"
+	Deletion     = "deletion"
+	Insertion    = "insertion"
+)
+
+// Edit is one word that differs between the expected text and the transcript.
+type Edit struct {
+	Kind     string `json:"kind"`
+	Expected string `json:"expected,omitempty"`
+	Got      string `json:"got,omitempty"`
+}
+
+// Result counts the edits needed to turn the expected words into the transcript.
+type Result struct {
+	ReferenceWords int    `json:"reference_words"`
+	Substitutions  int    `json:"substitutions"`
+	Deletions      int    `json:"deletions"`
+	Insertions     int    `json:"insertions"`
+	Edits          []Edit `json:"edits,omitempty"`
+}
+
+// Rate is (S+D+I)/N. An empty reference scores 0 for an empty transcript and 1 otherwise.
+func (r Result) Rate() float64 {
+	errs := r.Substitutions + r.Deletions + r.Insertions
+	if r.ReferenceWords == 0 {
+		if errs == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(errs) / float64(r.ReferenceWords)
+}
+
+// Normalize lowercases text, drops [tags] and (audio events), and splits it into words without punctuation.
+func Normalize(text string) []string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == '[' || r == '(':
+			depth++
+			b.WriteRune(' ')
+		case r == ']' || r == ')':
+			if depth > 0 {
+				depth--
+			}
+			b.WriteRune(' ')
+		case depth > 0:
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' || r == '’':
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	fields := strings.Fields(b.String())
+	words := fields[:0]
+	for _, f := range fields {
+		if f = strings.Trim(f, "'’"); f != "" {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// Compare aligns the normalized words of reference and hypothesis with a minimum edit distance.
+func Compare(reference, hypothesis string) Result {
+	ref, hyp := Normalize(reference), Normalize(hypothesis)
+	n, m := len(ref), len(hyp)
+
+	// dist[i][j] is the edit distance between ref[:i] and hyp[:j].
+	dist := make([][]int, n+1)
+	for i := range dist {
+		dist[i] = make([]int, m+1)
+		dist[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		dist[0][j] = j
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+			dist[i][j] = min(dist[i-1][j-1]+cost, dist[i-1][j]+1, dist[i][j-1]+1)
+		}
+	}
+
+	res := Result{ReferenceWords: n}
+	var edits []Edit
+	for i, j := n, m; i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && ref[i-1] == hyp[j-1] && dist[i][j] == dist[i-1][j-1]:
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			res.Substitutions++
+			edits = append(edits, Edit{Kind: Substitution, Expected: ref[i-1], Got: hyp[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && dist[i][j] == dist[i-1][j]+1:
+			res.Deletions++
+			edits = append(edits, Edit{Kind: Deletion, Expected: ref[i-1]})
+			i--
+		default:
+			res.Insertions++
+			edits = append(edits, Edit{Kind: Insertion, Got: hyp[j-1]})
+			j--
+		}
+	}
+	for l, r := 0, len(edits)-1; l < r; l, r = l+1, r-1 {
+		edits[l], edits[r] = edits[r], edits[l]
+	}
+	res.Edits = edits
+	return res
+}
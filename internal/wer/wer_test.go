@@ -0,0 +1,57 @@
+package wer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	got := Normalize("[excited] Hello, Dr. O'Brien! (laughs) It's 42 — really?")
+	want := []string{"hello", "dr", "o'brien", "it's", "42", "really"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Normalize = %q, want %q", got, want)
+	}
+}
+
+func TestCompareExactMatchIgnoresCaseAndPunctuation(t *testing.T) {
+	res := Compare("Hello, world.", "hello world")
+	if res.Rate() != 0 || len(res.Edits) != 0 || res.ReferenceWords != 2 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestCompareCountsEdits(t *testing.T) {
+	res := Compare("meet Siobhan at 7 tonight", "meet shiv on at 7 tonight please")
+	if res.Substitutions != 1 || res.Insertions != 2 || res.Deletions != 0 {
+		t.Fatalf("unexpected counts %+v", res)
+	}
+	if got := res.Rate(); got != 3.0/5.0 {
+		t.Fatalf("Rate = %v, want 0.6", got)
+	}
+	var sub Edit
+	for _, e := range res.Edits {
+		if e.Kind == Substitution {
+			sub = e
+		}
+	}
+	if sub.Expected != "siobhan" {
+		t.Fatalf("expected siobhan to be substituted: %+v", res.Edits)
+	}
+	if last := res.Edits[len(res.Edits)-1]; last != (Edit{Kind: Insertion, Got: "please"}) {
+		t.Fatalf("edits out of order: %+v", res.Edits)
+	}
+
+	res = Compare("one two three", "one three")
+	if res.Deletions != 1 || res.Edits[0] != (Edit{Kind: Deletion, Expected: "two"}) {
+		t.Fatalf("unexpected deletion result %+v", res)
+	}
+}
+
+func TestRateEmptyReference(t *testing.T) {
+	if r := Compare("", "").Rate(); r != 0 {
+		t.Fatalf("empty/empty rate = %v", r)
+	}
+	if r := Compare("", "noise").Rate(); r != 1 {
+		t.Fatalf("empty/insert rate = %v", r)
+	}
+}